@@ -0,0 +1,384 @@
+package main
+
+import (
+	"bytes"
+	"errors"
+	"fmt"
+	"github.com/jjcinaz/gosnmpHelper/internal/oids"
+	"github.com/jjcinaz/gosnmpHelper/mib"
+	"go/format"
+	"regexp"
+	"sort"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// genConfig holds the options of the gen subcommand
+type genConfig struct {
+	module  string
+	tables  []string
+	enums   []string
+	pkg     string
+	rows    bool // row structs with an oidtable tag rather than oidx maps
+	names   bool // MIB names in tags rather than numeric OIDs
+	command string
+}
+
+// Go types for textual conventions, which take precedence over the base type
+var tcGoTypes = map[string]string{
+	"DisplayString":    "string",
+	"SnmpAdminString":  "string",
+	"OwnerString":      "string",
+	"PhysAddress":      "net.HardwareAddr",
+	"MacAddress":       "net.HardwareAddr",
+	"DateAndTime":      "time.Time",
+	"TimeStamp":        "time.Duration",
+	"TimeInterval":     "int",
+	"AutonomousType":   "string",
+	"RowPointer":       "string",
+	"VariablePointer":  "string",
+	"InstancePointer":  "string",
+	"InterfaceIndex":   "int",
+	"IANAifType":       "int",
+	"InetAddressIPv4":  "net.IP",
+	"InetAddressIPv6":  "net.IP",
+	"InetAddress":      "net.IP",
+	"TruthValue":       "int",
+	"RowStatus":        "int",
+	"StorageType":      "int",
+	"TAddress":         "[]byte",
+	"SnmpEngineID":     "[]byte",
+	"TDomain":          "string",
+	"InetAddressType":  "int",
+	"InetPortNumber":   "uint",
+	"PhysicalIndex":    "int",
+	"PhysicalClass":    "int",
+	"EntPhysicalIndex": "int",
+}
+
+// Go types for the SMI base types
+var baseGoTypes = map[string]string{
+	"INTEGER":           "int",
+	"Integer32":         "int",
+	"Unsigned32":        "uint",
+	"Gauge32":           "uint",
+	"Gauge":             "uint",
+	"Counter32":         "uint",
+	"Counter":           "uint",
+	"Counter64":         "uint64",
+	"TimeTicks":         "time.Duration",
+	"IpAddress":         "net.IP",
+	"NetworkAddress":    "net.IP",
+	"OBJECT IDENTIFIER": "string",
+	"OCTET STRING":      "[]byte",
+	"BITS":              "[]byte",
+	"Opaque":            "[]byte",
+}
+
+// The imports needed for the package qualified Go types
+var typeImports = map[string]string{"net": "net", "time": "time"}
+
+// Integer base types, for which a single index is matched as digits
+var integerTypes = map[string]bool{"INTEGER": true, "Integer32": true, "Unsigned32": true, "Gauge32": true, "Gauge": true}
+
+// generator collects the generated declarations and the imports they need
+type generator struct {
+	tree      *mib.Tree
+	cfg       genConfig
+	body      bytes.Buffer
+	imports   map[string]bool
+	enumTypes map[string]string // enumerated object or textual convention name to its generated type
+}
+
+// generate returns the formatted Go source for the tables and groups named in cfg
+func generate(tree *mib.Tree, cfg genConfig) ([]byte, error) {
+	g := &generator{tree: tree, cfg: cfg, imports: map[string]bool{}, enumTypes: map[string]string{}}
+	var errs []error
+	for _, name := range cfg.enums {
+		if err := g.enum(name); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	for _, name := range cfg.tables {
+		n := tree.Node(cfg.module + "::" + name)
+		if n == nil || n.OID == nil {
+			errs = append(errs, fmt.Errorf("%w %s::%s", mib.ErrUnknownSymbol, cfg.module, name))
+			continue
+		}
+		var err error
+		if n.IsTable() {
+			err = g.table(n)
+		} else {
+			err = g.scalars(n)
+		}
+		if err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if len(errs) > 0 {
+		return nil, errors.Join(errs...)
+	}
+
+	var src bytes.Buffer
+	fmt.Fprintf(&src, "// Code generated by %s; DO NOT EDIT.\n\npackage %s\n\n", g.cfg.command, g.cfg.pkg)
+	if len(g.imports) > 0 {
+		imports := make([]string, 0, len(g.imports))
+		for imp := range g.imports {
+			imports = append(imports, strconv.Quote(imp))
+		}
+		sort.Strings(imports)
+		fmt.Fprintf(&src, "import (\n%s\n)\n\n", strings.Join(imports, "\n"))
+	}
+	src.Write(g.body.Bytes())
+	return format.Source(src.Bytes())
+}
+
+// enum generates a named integer type, with constants for its values, for an enumerated object or textual
+// convention
+func (g *generator) enum(name string) error {
+	var values []mib.Enum
+	var source string
+	if n := g.node(g.cfg.module, name); n != nil && n.BaseType() != "BITS" && len(n.Enums()) > 0 {
+		values, source = n.Enums(), n.Module+"::"+n.Name
+	} else if tc := g.textualConvention(name); tc != nil && tc.BaseType() != "BITS" && len(tc.Enums()) > 0 {
+		values, source = tc.Enums(), tc.Module+"::"+tc.Name
+	} else {
+		return fmt.Errorf("%w %s::%s with an enumeration", mib.ErrUnknownSymbol, g.cfg.module, name)
+	}
+	typ := goName(name)
+	names := unexported(typ) + "Names"
+	g.enumTypes[name] = typ
+	g.imports["strconv"] = true
+	fmt.Fprintf(&g.body, "// %s is the enumeration of %s\ntype %s int\n\n", typ, source, typ)
+	fmt.Fprintf(&g.body, "// The values of %s\nconst (\n", typ)
+	for _, v := range values {
+		fmt.Fprintf(&g.body, "%s%s %s = %d\n", typ, goName(v.Name), typ, v.Value)
+	}
+	fmt.Fprintf(&g.body, ")\n\nvar %s = map[int]string{\n", names)
+	for _, v := range values {
+		fmt.Fprintf(&g.body, "%d: %s,\n", v.Value, strconv.Quote(v.Name))
+	}
+	g.body.WriteString("}\n\n")
+	fmt.Fprintf(&g.body, `// String returns the name of the value, or the number for a value the MIB doesn't name
+func (e %[1]s) String() string {
+	if name, ok := %[2]s[int(e)]; ok {
+		return name
+	}
+	return strconv.Itoa(int(e))
+}
+
+// EnumNames returns the names of the values defined by the MIB
+func (%[1]s) EnumNames() map[int]string {
+	return %[2]s
+}
+
+`, typ, names)
+	return nil
+}
+
+// table generates the struct for a table, either as a map per column or as rows
+func (g *generator) table(table *mib.Node) error {
+	entry, _ := g.tree.Lookup(append(append([]uint32{}, table.OID...), 1))
+	if entry == nil || !entry.IsEntry() {
+		return fmt.Errorf("%s::%s: no table entry found", table.Module, table.Name)
+	}
+	index := entry.Index
+	if len(entry.Augments) > 0 {
+		if augmented := g.node(entry.Module, entry.Augments); augmented != nil {
+			index = augmented.Index
+		}
+	}
+	columns := g.children(entry)
+	if len(columns) == 0 {
+		return fmt.Errorf("%s::%s: no accessible columns", table.Module, table.Name)
+	}
+	indexRx := `(.+)`
+	if len(index) == 1 {
+		if n := g.node(entry.Module, index[0]); n != nil && integerTypes[n.BaseType()] {
+			indexRx = `(\d+)`
+		}
+	}
+	tableType := goName(table.Name)
+	if !g.cfg.rows {
+		fmt.Fprintf(&g.body, "// %s holds the columns of %s::%s, keyed by the %s index\n",
+			tableType, table.Module, table.Name, indexName(index))
+		fmt.Fprintf(&g.body, "type %s struct {\n", tableType)
+		for _, col := range columns {
+			g.comment(col)
+			pattern := `^` + regexp.QuoteMeta(oids.Format(col.OID)) + `\.` + indexRx + `$`
+			if g.cfg.names {
+				pattern = col.Module + "::" + col.Name + `.` + indexRx + `$`
+			}
+			fmt.Fprintf(&g.body, "%s map[string]%s `oidx:%s`\n", goName(col.Name), g.goType(col), strconv.Quote(pattern))
+		}
+		g.body.WriteString("}\n\n")
+		return nil
+	}
+
+	rowType := goName(entry.Name)
+	fmt.Fprintf(&g.body, "// %s is a row of %s::%s, indexed by %s\n", rowType, table.Module, table.Name, indexName(index))
+	fmt.Fprintf(&g.body, "type %s struct {\n", rowType)
+	g.body.WriteString("Index string `oidcol:\"index\"`\n")
+	for _, col := range columns {
+		g.comment(col)
+		column := strconv.FormatUint(uint64(col.OID[len(col.OID)-1]), 10)
+		if g.cfg.names {
+			column = col.Name
+		}
+		fmt.Fprintf(&g.body, "%s %s `oidcol:%s`\n", goName(col.Name), g.goType(col), strconv.Quote(column))
+	}
+	g.body.WriteString("}\n\n")
+	entryOID := oids.Format(entry.OID)
+	if g.cfg.names {
+		entryOID = entry.Module + "::" + entry.Name
+	}
+	fmt.Fprintf(&g.body, "// %s holds the rows of %s::%s, keyed by index\n", tableType, table.Module, table.Name)
+	fmt.Fprintf(&g.body, "type %s struct {\nRows map[string]%s `oidtable:%s`\n}\n\n", tableType, rowType, strconv.Quote(entryOID))
+	return nil
+}
+
+// scalars generates the struct for the scalar objects directly beneath a node, such as system
+func (g *generator) scalars(group *mib.Node) error {
+	var scalars []*mib.Node
+	for _, n := range g.children(group) {
+		if !n.IsTable() {
+			scalars = append(scalars, n)
+		}
+	}
+	if len(scalars) == 0 {
+		return fmt.Errorf("%s::%s: not a table and has no scalar objects", group.Module, group.Name)
+	}
+	typ := goName(group.Name)
+	fmt.Fprintf(&g.body, "// %s holds the scalar objects of %s::%s\n", typ, group.Module, group.Name)
+	fmt.Fprintf(&g.body, "type %s struct {\n", typ)
+	for _, n := range scalars {
+		g.comment(n)
+		oid := oids.Format(n.OID) + ".0"
+		if g.cfg.names {
+			oid = n.Module + "::" + n.Name + ".0"
+		}
+		fmt.Fprintf(&g.body, "%s %s `oid:%s`\n", goName(n.Name), g.goType(n), strconv.Quote(oid))
+	}
+	g.body.WriteString("}\n\n")
+	return nil
+}
+
+// children returns the accessible OBJECT-TYPEs directly beneath parent, in OID order
+func (g *generator) children(parent *mib.Node) []*mib.Node {
+	var result []*mib.Node
+	for _, m := range g.tree.Modules() {
+		for _, n := range m.Nodes {
+			if n.Kind != mib.KindObjectType || n.Syntax == nil || n.Access == "not-accessible" ||
+				len(n.OID) != len(parent.OID)+1 || oids.Compare(n.OID[:len(parent.OID)], parent.OID) != 0 {
+				continue
+			}
+			if existing, _ := g.tree.Lookup(n.OID); existing == n {
+				result = append(result, n)
+			}
+		}
+	}
+	sort.Slice(result, func(i, j int) bool { return oids.Compare(result[i].OID, result[j].OID) < 0 })
+	return result
+}
+
+// node finds a name used by module, such as an INDEX object
+func (g *generator) node(module string, name string) *mib.Node {
+	if n := g.tree.Node(module + "::" + name); n != nil {
+		return n
+	}
+	return g.tree.Node(name)
+}
+
+// textualConvention finds a textual convention, preferring the one defined by the module being generated
+func (g *generator) textualConvention(name string) *mib.TextualConvention {
+	var found *mib.TextualConvention
+	for _, m := range g.tree.Modules() {
+		for _, tc := range m.Types {
+			if tc.Name == name && (found == nil || m.Name == g.cfg.module) {
+				found = tc
+			}
+		}
+	}
+	return found
+}
+
+// comment writes the first sentence of the node's description as a comment
+func (g *generator) comment(n *mib.Node) {
+	desc := strings.Join(strings.Fields(n.Description), " ")
+	if i := strings.Index(desc, ". "); i >= 0 {
+		desc = desc[:i+1]
+	}
+	if len(desc) == 0 {
+		fmt.Fprintf(&g.body, "// %s\n", n.Name)
+		return
+	}
+	fmt.Fprintf(&g.body, "// %s: %s\n", n.Name, desc)
+}
+
+// goType returns the Go type for the node's SYNTAX, noting any import needed
+func (g *generator) goType(n *mib.Node) string {
+	typ := g.enumTypes[n.Name]
+	for tc := n.TC; tc != nil && len(typ) == 0; tc = tc.Parent {
+		if typ = g.enumTypes[tc.Name]; len(typ) == 0 {
+			typ = tcGoTypes[tc.Name]
+		}
+	}
+	if len(typ) == 0 && n.BaseType() == "OCTET STRING" && isTextHint(n.DisplayHint()) {
+		typ = "string"
+	}
+	if len(typ) == 0 {
+		if typ = baseGoTypes[n.BaseType()]; len(typ) == 0 {
+			typ = "[]byte"
+		}
+	}
+	if pkg, _, ok := strings.Cut(typ, "."); ok {
+		g.imports[typeImports[pkg]] = true
+	}
+	return typ
+}
+
+// isTextHint reports whether a DISPLAY-HINT formats the whole string as text, such as "255a"
+func isTextHint(hint string) bool {
+	return len(hint) > 1 && (strings.HasSuffix(hint, "a") || strings.HasSuffix(hint, "t")) &&
+		len(strings.TrimLeft(hint[:len(hint)-1], "0123456789")) == 0
+}
+
+// goName converts a MIB name such as ifHCInOctets or mib-2 to an exported Go name
+func goName(name string) string {
+	var sb strings.Builder
+	upper := true
+	for _, r := range name {
+		if r == '-' || r == '_' {
+			upper = true
+			continue
+		}
+		if upper {
+			r = unicode.ToUpper(r)
+			upper = false
+		}
+		sb.WriteRune(r)
+	}
+	return sb.String()
+}
+
+// unexported converts a Go name to its unexported form, such as ifOperStatus for IfOperStatus or ianaifType for
+// IANAifType
+func unexported(name string) string {
+	i := strings.IndexFunc(name, unicode.IsLower)
+	if i < 0 {
+		return strings.ToLower(name)
+	}
+	if i == 0 {
+		return name
+	}
+	return strings.ToLower(name[:i]) + name[i:]
+}
+
+// indexName describes the index of a table
+func indexName(index []string) string {
+	if len(index) == 0 {
+		return "instance"
+	}
+	return strings.Join(index, "+")
+}
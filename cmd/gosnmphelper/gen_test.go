@@ -0,0 +1,126 @@
+package main
+
+import (
+	"bytes"
+	"go/parser"
+	"go/token"
+	"strings"
+	"testing"
+)
+
+func TestGen(t *testing.T) {
+	tests := []struct {
+		name     string
+		args     []string
+		want     []string
+		wantCode int
+	}{
+		{
+			name: "oidx maps",
+			args: []string{"-mib", "IF-MIB", "-table", "ifTable,ifXTable", "-pkg", "device"},
+			want: []string{
+				"// Code generated by gosnmphelper gen -M ../../mib/testdata -mib IF-MIB -table ifTable,ifXTable -pkg device; DO NOT EDIT.",
+				"package device",
+				`"net"`,
+				"type IfTable struct {",
+				"// ifDescr: A textual string containing information about the interface.",
+				"IfDescr map[string]string `oidx:\"^\\\\.1\\\\.3\\\\.6\\\\.1\\\\.2\\\\.1\\\\.2\\\\.2\\\\.1\\\\.2\\\\.(\\\\d+)$\"`",
+				"IfPhysAddress map[string]net.HardwareAddr `oidx:",
+				"IfLastChange map[string]time.Duration `oidx:",
+				"IfHCInOctets map[string]uint64 `oidx:\"^\\\\.1\\\\.3\\\\.6\\\\.1\\\\.2\\\\.1\\\\.31\\\\.1\\\\.1\\\\.1\\\\.6\\\\.(\\\\d+)$\"`",
+			},
+		},
+		{
+			name: "rows",
+			args: []string{"-mib", "IF-MIB", "-table", "ifTable", "-rows"},
+			want: []string{
+				"type IfEntry struct {",
+				"Index string `oidcol:\"index\"`",
+				"IfSpeed uint `oidcol:\"5\"`",
+				"IfOperStatus int `oidcol:\"8\"`",
+				"type IfTable struct {",
+				"Rows map[string]IfEntry `oidtable:\".1.3.6.1.2.1.2.2.1\"`",
+			},
+		},
+		{
+			name: "names",
+			args: []string{"-mib", "IF-MIB", "-table", "ifXTable", "-rows", "-names"},
+			want: []string{
+				"IfName string `oidcol:\"ifName\"`",
+				"Rows map[string]IfXEntry `oidtable:\"IF-MIB::ifXEntry\"`",
+			},
+		},
+		{
+			name: "scalars",
+			args: []string{"-mib", "SNMPv2-MIB", "-table", "system", "-names"},
+			want: []string{
+				"type System struct {",
+				"SysDescr string `oid:\"SNMPv2-MIB::sysDescr.0\"`",
+				"SysUpTime time.Duration `oid:\"SNMPv2-MIB::sysUpTime.0\"`",
+			},
+		},
+		{
+			name: "enums",
+			args: []string{"-mib", "ENTITY-MIB", "-enum", "PhysicalClass,entPhysicalIsFRU", "-table", "entPhysicalTable", "-rows"},
+			want: []string{
+				`"strconv"`,
+				"type PhysicalClass int",
+				"PhysicalClassPowerSupply  PhysicalClass = 6",
+				"var physicalClassNames = map[int]string{",
+				"func (e PhysicalClass) String() string {",
+				"func (PhysicalClass) EnumNames() map[int]string {",
+				"type EntPhysicalIsFRU int",
+				"EntPhysicalClass PhysicalClass `oidcol:\"5\"`",
+				"EntPhysicalIsFRU EntPhysicalIsFRU `oidcol:\"16\"`",
+				"EntPhysicalContainedIn int `oidcol:\"4\"`",
+			},
+		},
+		{name: "Unknown table", args: []string{"-mib", "IF-MIB", "-table", "noTable"}, wantCode: 1},
+		{name: "Unknown module", args: []string{"-mib", "NO-SUCH-MIB", "-table", "noTable"}, wantCode: 1},
+		{name: "Not enumerated", args: []string{"-mib", "IF-MIB", "-enum", "ifDescr"}, wantCode: 1},
+		{name: "No table", args: []string{"-mib", "IF-MIB"}, wantCode: 2},
+		{name: "Bad flag", args: []string{"-bad"}, wantCode: 2},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var stdout, stderr bytes.Buffer
+			args := append([]string{"gen", "-M", "../../mib/testdata"}, tt.args...)
+			if code := run(args, &stdout, &stderr); code != tt.wantCode {
+				t.Fatalf("run() = %d, want %d: %s", code, tt.wantCode, stderr.String())
+			}
+			if tt.wantCode != 0 {
+				if stderr.Len() == 0 {
+					t.Errorf("run() gave no error message")
+				}
+				return
+			}
+			src := stdout.String()
+			for _, want := range tt.want {
+				if !strings.Contains(src, want) {
+					t.Errorf("output lacks %s\n%s", want, src)
+				}
+			}
+			if _, err := parser.ParseFile(token.NewFileSet(), "gen.go", src, 0); err != nil {
+				t.Errorf("generated code does not parse: %v", err)
+			}
+		})
+	}
+	if code := run(nil, new(bytes.Buffer), new(bytes.Buffer)); code != 2 {
+		t.Errorf("run() with no subcommand = %d, want 2", code)
+	}
+}
+
+func TestGoName(t *testing.T) {
+	tests := map[string]string{"ifHCInOctets": "IfHCInOctets", "mib-2": "Mib2", "system": "System", "x": "X"}
+	for name, want := range tests {
+		if got := goName(name); got != want {
+			t.Errorf("goName(%s) = %s, want %s", name, got, want)
+		}
+	}
+	tests = map[string]string{"IfOperStatus": "ifOperStatus", "IANAifType": "ianaifType", "ABC": "abc"}
+	for name, want := range tests {
+		if got := unexported(name); got != want {
+			t.Errorf("unexported(%s) = %s, want %s", name, got, want)
+		}
+	}
+}
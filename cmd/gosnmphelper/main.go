@@ -0,0 +1,101 @@
+/*
+Command gosnmphelper has tools for working with gosnmpHelper.  The gen subcommand writes Go struct types
+with oid, oidx or oidtable tags for MIB tables and scalar groups, so the escaped regular expressions don't
+have to be written by hand:
+
+	gosnmphelper gen -mib IF-MIB -table ifTable,ifXTable -pkg device -o iftable.go
+
+It also writes named integer types, with constants and a String method, for enumerated objects and textual
+conventions.  Table columns with an enumeration generated in the same run are given its type:
+
+	gosnmphelper gen -mib IF-MIB -enum ifOperStatus,IANAifType -table ifTable -o iftable.go
+
+The MIB files are read from the directories in -M, which defaults to $MIBDIRS or /usr/share/snmp/mibs.  Run
+"gosnmphelper gen -h" for all the options.
+*/
+package main
+
+import (
+	"flag"
+	"fmt"
+	"github.com/jjcinaz/gosnmpHelper/mib"
+	"io"
+	"os"
+	"path/filepath"
+	"strings"
+)
+
+func main() {
+	os.Exit(run(os.Args[1:], os.Stdout, os.Stderr))
+}
+
+// run carries out the command line and returns the exit status
+func run(args []string, stdout io.Writer, stderr io.Writer) int {
+	if len(args) == 0 || args[0] != "gen" {
+		fmt.Fprintln(stderr, "usage: gosnmphelper gen -mib MODULE [-table NAME,...] [-enum NAME,...] [flags]")
+		return 2
+	}
+	flags := flag.NewFlagSet("gen", flag.ContinueOnError)
+	flags.SetOutput(stderr)
+	defaultDirs := os.Getenv("MIBDIRS")
+	if len(defaultDirs) == 0 {
+		defaultDirs = "/usr/share/snmp/mibs"
+	}
+	var (
+		module = flags.String("mib", "", "MIB module defining the tables, such as IF-MIB")
+		tables = flags.String("table", "", "comma separated tables or scalar groups, such as ifTable,system")
+		enums  = flags.String("enum", "", "comma separated enumerated objects or textual conventions, such as ifOperStatus,IANAifType")
+		dirs   = flags.String("M", defaultDirs, "directories holding MIB files, separated by "+string(os.PathListSeparator))
+		output = flags.String("o", "", "output file, rather than standard output")
+		cfg    genConfig
+	)
+	flags.StringVar(&cfg.pkg, "pkg", "main", "package name of the generated code")
+	flags.BoolVar(&cfg.rows, "rows", false, "generate row structs with an oidtable tag rather than oidx maps")
+	flags.BoolVar(&cfg.names, "names", false, "use MIB names in the tags rather than numeric OIDs")
+	if err := flags.Parse(args[1:]); err != nil {
+		return 2
+	}
+	if len(*module) == 0 || (len(*tables) == 0 && len(*enums) == 0) {
+		fmt.Fprintln(stderr, "gen: -mib and either -table or -enum are required")
+		flags.Usage()
+		return 2
+	}
+	cfg.module = *module
+	cfg.tables = splitList(*tables)
+	cfg.enums = splitList(*enums)
+	cfg.command = "gosnmphelper " + strings.Join(args, " ")
+
+	tree := mib.NewTree(filepath.SplitList(*dirs)...)
+	if err := tree.Load(*module); err != nil {
+		fmt.Fprintf(stderr, "gen: %v\n", err)
+		if tree.Module(*module) == nil {
+			return 1
+		}
+	}
+	src, err := generate(tree, cfg)
+	if err != nil {
+		fmt.Fprintf(stderr, "gen: %v\n", err)
+		return 1
+	}
+	if len(*output) == 0 {
+		_, err = stdout.Write(src)
+	} else {
+		err = os.WriteFile(*output, src, 0o644)
+	}
+	if err != nil {
+		fmt.Fprintf(stderr, "gen: %v\n", err)
+		return 1
+	}
+	return 0
+}
+
+// splitList splits a comma separated flag value, dropping empty items
+func splitList(s string) []string {
+	var result []string
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); len(item) > 0 {
+			result = append(result, item)
+		}
+	}
+	return result
+}
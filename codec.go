@@ -0,0 +1,517 @@
+package gosnmpHelper
+
+import (
+	"errors"
+	"fmt"
+	"github.com/gosnmp/gosnmp"
+	"math"
+	"reflect"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// Codecs already built, keyed by struct type
+var codecs sync.Map
+
+// Codecs built by legacyCodec() for types NewCodec() rejects, keyed by struct type
+var legacyCodecs sync.Map
+
+/*
+A Codec holds the analyzed oid, oidx and oidtable tags of a struct type so that PDUs can be marshaled into
+the struct without re-reflecting the struct tags or recompiling oidx patterns on every call.  Codecs are
+cached, so calling NewCodec() repeatedly for the same type is cheap.  The package level functions such as
+MarshalPDUToStruct() and GetOidsFromStructTags() use a Codec internally.
+
+A Codec is safe for concurrent use, though marshaling into the same destination struct from multiple
+goroutines is not.
+
+	codec, err := NewCodec(reflect.TypeOf(SysInfo{}))
+	...
+	err = client.BulkWalk(".1.3.6.1.2.1.17.4.3.1", func(pdu gosnmp.SnmpPDU) error {
+		_, err := codec.Marshal(pdu, &info)
+		return err
+	})
+*/
+type Codec struct {
+	typ    reflect.Type
+	fields []codecField
+}
+
+type fieldKind int
+
+const (
+	fieldIgnored fieldKind = iota // not eligible for PDU values
+	fieldOid                      // oid tag matched exactly against the PDU name
+	fieldOidx                     // oidx tag matched as a regular expression against the PDU name
+	fieldTable                    // oidtable tag on a map or slice of row structs
+	fieldStruct                   // nested struct
+	fieldPtr                      // pointer to nested struct
+)
+
+type codecField struct {
+	index  int
+	name   string
+	kind   fieldKind
+	oid    string // oid tag as written, or the resolved OID for a symbolic name
+	match  string // oid tag in canonical form for matching
+	rx     *regexp.Regexp
+	walk   string // walk tag, overriding the subtree derived for oidx and oidtable members
+	table  *tableInfo
+	nested *Codec
+	enum   map[int]string // names from an enum tag
+	hint   *displayHint   // from a hint tag
+	// For oidx maps keyed by a struct, the key member receiving each capture group
+	keyFields []int
+}
+
+// tableInfo describes a member with an oidtable tag
+type tableInfo struct {
+	entry   string                    // OID of the table entry
+	columns map[string]int            // column sub-identifier to row struct field number
+	index   int                       // field number of the row index member or -1
+	enums   map[string]map[int]string // column sub-identifier to the names from an enum tag
+	hints   map[string]*displayHint   // column sub-identifier to the hint from a hint tag
+}
+
+/*
+Returns the Codec for the struct type t, which may also be a pointer to a struct type.  ErrNotStruct is
+returned for any other type.
+
+Tags may give symbolic names in place of numeric OIDs, such as `oid:"SNMPv2-MIB::sysDescr.0"`,
+`oidx:"IF-MIB::ifDescr.(\\d+)"` and `oidtable:"IF-MIB::ifEntry"`, with the oidcol tags of the row giving
+column names such as `oidcol:"ifDescr"`.  See RegisterNames() for the names known.  An oidx pattern starting
+with a name is anchored at the start of the PDU name.  An error wrapping ErrUnknownName is returned if any
+names cannot be resolved, and the type is not cached so it can be retried once the names are registered.
+
+Members with oidx tags holding invalid regular expressions never match any PDU.
+*/
+func NewCodec(t reflect.Type) (*Codec, error) {
+	if t != nil && t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if t == nil || t.Kind() != reflect.Struct {
+		return nil, ErrNotStruct
+	}
+	if c, ok := codecs.Load(t); ok {
+		return c.(*Codec), nil
+	}
+	c, _, err := buildCodec(t, &codecBuilder{depth: map[reflect.Type]int{}})
+	if err != nil {
+		return nil, err
+	}
+	actual, _ := codecs.LoadOrStore(t, c)
+	return actual.(*Codec), nil
+}
+
+/*
+legacyCodec returns the Codec for the struct type t for the functions which predate NewCodec() and don't
+return errors, such as GetOidsFromStructTags() and MarshalPDUToStruct().  If NewCodec() fails, the members
+whose tags can't be used are left out rather than failing, as those functions always have.  ErrNotStruct is
+still returned for other types.  NewCodec() is tried each time so that names registered later are used.
+*/
+func legacyCodec(t reflect.Type) (*Codec, error) {
+	c, err := NewCodec(t)
+	if err == nil || errors.Is(err, ErrNotStruct) {
+		return c, err
+	}
+	if t.Kind() == reflect.Ptr {
+		t = t.Elem()
+	}
+	if c, ok := legacyCodecs.Load(t); ok {
+		return c.(*Codec), nil
+	}
+	c, _, _ = buildCodec(t, &codecBuilder{depth: map[reflect.Type]int{}, lenient: true})
+	actual, _ := legacyCodecs.LoadOrStore(t, c)
+	return actual.(*Codec), nil
+}
+
+// Compile returns the Codec for the struct type T.  See NewCodec() for details.
+func Compile[T any]() (*Codec, error) {
+	return NewCodec(reflect.TypeOf((*T)(nil)).Elem())
+}
+
+/*
+codecBuilder tracks the struct types currently being built so that self-referencing types don't recurse
+forever; members referring back to a type being built are ignored.  A nested Codec which lost members to a
+type built further out only describes the type as seen from there, so it mustn't be cached.
+*/
+type codecBuilder struct {
+	depth   map[reflect.Type]int // nesting depth of each type being built
+	cut     int                  // shallowest depth referred back to since the current build started
+	lenient bool                 // leave out members with unusable tags, for legacyCodec()
+}
+
+// buildCodec analyzes the struct type t, reporting whether the Codec can be cached as the Codec for t.  The
+// errors for all names which could not be resolved are joined into the returned error.
+func buildCodec(t reflect.Type, b *codecBuilder) (*Codec, bool, error) {
+	c := &Codec{typ: t, fields: make([]codecField, 0, t.NumField())}
+	depth := len(b.depth)
+	b.depth[t] = depth
+	defer delete(b.depth, t)
+	outerCut := b.cut
+	b.cut = math.MaxInt
+	var errs []error
+	for i := 0; i < t.NumField(); i++ {
+		fInfo := t.Field(i)
+		if fInfo.PkgPath != "" {
+			// unexported
+			continue
+		}
+		f := codecField{index: i, name: fInfo.Name}
+		if err := f.build(fInfo, b); err != nil {
+			if !b.lenient {
+				errs = append(errs, fmt.Errorf("%s: %w", fInfo.Name, err))
+			}
+			continue
+		}
+		if f.kind == fieldOidx && f.rx == nil {
+			f.kind = fieldIgnored
+		}
+		if f.kind != fieldIgnored || len(f.oid) > 0 {
+			c.fields = append(c.fields, f)
+		}
+	}
+	cacheable := b.cut >= depth
+	if b.cut > outerCut {
+		b.cut = outerCut
+	}
+	return c, cacheable, errors.Join(errs...)
+}
+
+// build sets up the member from its type and tags
+func (f *codecField) build(fInfo reflect.StructField, b *codecBuilder) error {
+	var err error
+	if f.oid, err = resolveTag(fInfo.Tag.Get("oid")); err != nil {
+		return err
+	}
+	if f.walk, err = resolveTag(fInfo.Tag.Get("walk")); err != nil {
+		return err
+	}
+	f.match = canonicalOID(f.oid)
+	if tag := fInfo.Tag.Get("enum"); len(tag) > 0 {
+		if f.enum, err = parseEnumTag(tag); err != nil {
+			return err
+		}
+	}
+	if tag := fInfo.Tag.Get("hint"); len(tag) > 0 {
+		if f.hint, err = parseDisplayHint(tag); err != nil {
+			return err
+		}
+	}
+	_, isValueType := valueTypes[fInfo.Type]
+	switch kind := fInfo.Type.Kind(); {
+	case isValueType:
+		return f.setScalar(fInfo.Tag)
+	case kind == reflect.Map || kind == reflect.Slice:
+		if table := fInfo.Tag.Get("oidtable"); len(table) > 0 {
+			f.kind = fieldTable
+			f.table, err = newTableInfo(table, fInfo.Type.Elem())
+		} else if pattern := oidxPattern(fInfo.Tag); len(pattern) > 0 {
+			f.kind = fieldOidx
+			if f.rx, err = compileOidx(pattern); f.rx != nil && fInfo.Type.Kind() == reflect.Map &&
+				fInfo.Type.Key().Kind() == reflect.Struct {
+				f.keyFields = structKeyFields(fInfo.Type.Key(), f.rx.SubexpNames()[1:])
+			}
+		} else if len(f.oid) > 0 && fInfo.Type.Kind() == reflect.Slice {
+			f.kind = fieldOid
+		}
+	case isScalarKind(kind):
+		return f.setScalar(fInfo.Tag)
+	case kind == reflect.Struct:
+		if f.nested, err = nestedCodec(fInfo.Type, b); f.nested != nil {
+			f.kind = fieldStruct
+		}
+	case kind == reflect.Ptr:
+		// We only deal with pointers to structs here
+		if fInfo.Type.Elem().Kind() == reflect.Struct {
+			if f.nested, err = nestedCodec(fInfo.Type.Elem(), b); f.nested != nil {
+				f.kind = fieldPtr
+			}
+		}
+	}
+	return err
+}
+
+// isScalarKind reports whether members of the kind hold a single numeric or string PDU value
+func isScalarKind(kind reflect.Kind) bool {
+	switch kind {
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Float32, reflect.Float64,
+		reflect.String:
+		return true
+	}
+	return false
+}
+
+// setScalar sets up a member which holds a single PDU value, matched by either an oid or oidx tag
+func (f *codecField) setScalar(tag reflect.StructTag) error {
+	var err error
+	if len(f.oid) > 0 {
+		f.kind = fieldOid
+	} else if pattern := oidxPattern(tag); len(pattern) > 0 {
+		f.kind = fieldOidx
+		f.rx, err = compileOidx(pattern)
+	}
+	return err
+}
+
+// compileOidx compiles an oidx pattern after resolving any name at its start.  An invalid regular
+// expression gives a nil result without an error.
+func compileOidx(pattern string) (*regexp.Regexp, error) {
+	pattern, err := resolvePattern(pattern)
+	if err != nil {
+		return nil, err
+	}
+	rx, _ := regexp.Compile(pattern)
+	return rx, nil
+}
+
+// nestedCodec returns the Codec for a nested struct type, or nil if t refers back to a type being built
+func nestedCodec(t reflect.Type, b *codecBuilder) (*Codec, error) {
+	if depth, ok := b.depth[t]; ok {
+		if depth < b.cut {
+			b.cut = depth
+		}
+		return nil, nil
+	}
+	if c, ok := codecs.Load(t); ok {
+		return c.(*Codec), nil
+	}
+	c, cacheable, err := buildCodec(t, b)
+	if err != nil {
+		return nil, err
+	}
+	if !cacheable || b.lenient {
+		// A lenient Codec may have lost members that NewCodec() would reject the type for
+		return c, nil
+	}
+	actual, _ := codecs.LoadOrStore(t, c)
+	return actual.(*Codec), nil
+}
+
+// newTableInfo collects the oidcol tags of a table row type.  Column names are looked up in the module of
+// a symbolic entry unless they give their own module.
+func newTableInfo(entry string, rowT reflect.Type) (*tableInfo, error) {
+	resolved, err := resolveTag(entry)
+	if err != nil {
+		return nil, err
+	}
+	table := &tableInfo{entry: canonicalOID(resolved), columns: map[string]int{}, index: -1,
+		enums: map[string]map[int]string{}, hints: map[string]*displayHint{}}
+	if rowT.Kind() != reflect.Struct {
+		return table, nil
+	}
+	module, _, _ := strings.Cut(entry, "::")
+	var errs []error
+	for i := 0; i < rowT.NumField(); i++ {
+		sub := ""
+		switch col := rowT.Field(i).Tag.Get("oidcol"); {
+		case col == "":
+		case col == "index":
+			table.index = i
+		case len(strings.Trim(col, "0123456789")) == 0:
+			sub = col
+		default:
+			if sub, err = columnName(table.entry, module, col); err != nil {
+				errs = append(errs, fmt.Errorf("%s: %w", rowT.Field(i).Name, err))
+			}
+		}
+		if len(sub) == 0 {
+			continue
+		}
+		table.columns[sub] = i
+		if tag := rowT.Field(i).Tag.Get("enum"); len(tag) > 0 {
+			if names, err := parseEnumTag(tag); err != nil {
+				errs = append(errs, fmt.Errorf("%s: %w", rowT.Field(i).Name, err))
+			} else {
+				table.enums[sub] = names
+			}
+		}
+		if tag := rowT.Field(i).Tag.Get("hint"); len(tag) > 0 {
+			if h, err := parseDisplayHint(tag); err != nil {
+				errs = append(errs, fmt.Errorf("%s: %w", rowT.Field(i).Name, err))
+			} else {
+				table.hints[sub] = h
+			}
+		}
+	}
+	return table, errors.Join(errs...)
+}
+
+// columnName returns the column sub-identifier for a column name, which must be directly beneath entry
+func columnName(entry string, module string, col string) (string, error) {
+	name := col
+	if !isSymbolic(col) {
+		name = module + "::" + col
+	}
+	oid, err := ResolveName(name)
+	if err != nil {
+		return "", err
+	}
+	entryOid, err := ParseOID(entry)
+	if err != nil || len(oid) != len(entryOid)+1 || !oid.HasPrefix(entryOid) {
+		return "", fmt.Errorf("%w %q is not a column of %s", ErrUnknownName, col, entry)
+	}
+	return oid.TrimPrefix(entryOid).StringNoDot(), nil
+}
+
+// structKeyFields maps the capture groups of an oidx pattern onto the members of a struct map key.  Named
+// groups go to the member of the same name, ignoring case.  Unnamed groups fill the exported members in order.
+func structKeyFields(keyT reflect.Type, groups []string) []int {
+	exported := make([]int, 0, keyT.NumField())
+	for i := 0; i < keyT.NumField(); i++ {
+		if keyT.Field(i).PkgPath == "" {
+			exported = append(exported, i)
+		}
+	}
+	result := make([]int, len(groups))
+	next := 0
+	for g, name := range groups {
+		result[g] = -1
+		if len(name) > 0 {
+			for _, i := range exported {
+				if strings.EqualFold(keyT.Field(i).Name, name) {
+					result[g] = i
+				}
+			}
+		} else if next < len(exported) {
+			result[g] = exported[next]
+			next++
+		}
+	}
+	return result
+}
+
+// oidxPattern returns the regular expression of an oidx tag, in either the quoted or unquoted format
+func oidxPattern(tag reflect.StructTag) string {
+	if !strings.HasPrefix(string(tag), "oidx:") {
+		return ""
+	}
+	if pattern := tag.Get("oidx"); len(pattern) > 0 {
+		return pattern
+	}
+	return string(tag)[5:]
+}
+
+/*
+Copies the value of the PDU into the matching member of dest, which must be a pointer to the struct type
+of the Codec.  See MarshalPDUToStructE() for details on the returned values.
+
+The PDU name is matched with or without a leading dot, so ".1.3.6.1.2.1.1.1.0" and "1.3.6.1.2.1.1.1.0"
+both match the tag oid:".1.3.6.1.2.1.1.1.0".  Patterns in oidx tags are matched against the name with a
+leading dot.
+*/
+func (c *Codec) Marshal(pdu gosnmp.SnmpPDU, dest interface{}) (bool, error) {
+	return c.marshalRows(pdu, dest, nil)
+}
+
+// marshalRows is Marshal() for callers marshaling a series of PDUs into dest, which pass the same rows for
+// each so that the rows of []Row members are found without searching.  With nil rows they are searched.
+func (c *Codec) marshalRows(pdu gosnmp.SnmpPDU, dest interface{}, rows rowIndexes) (bool, error) {
+	destV := reflect.ValueOf(dest)
+	if destV.Kind() != reflect.Ptr || destV.IsNil() || destV.Type().Elem() != c.typ {
+		return false, ErrNotPointer
+	}
+	pdu.Name = canonicalOID(pdu.Name)
+	return c.marshal(pdu, destV.Elem(), "", rows)
+}
+
+// marshal does the work of Marshal() on the struct value v.  The path is the location of v within the
+// outermost struct and is used when reporting errors.
+func (c *Codec) marshal(pdu gosnmp.SnmpPDU, v reflect.Value, path string, rows rowIndexes) (bool, error) {
+	for i := range c.fields {
+		f := &c.fields[i]
+		switch f.kind {
+		case fieldOid:
+			if f.match == pdu.Name {
+				fv := v.Field(f.index)
+				if f.enum != nil {
+					pdu = enumPDU(f.enum, pdu, fv.Type())
+				}
+				if f.hint != nil {
+					pdu = hintPDU(f.hint, pdu, fv.Type())
+				}
+				return true, setValue(pdu, fv, joinPath(path, f.name))
+			}
+		case fieldOidx:
+			if m := f.rx.FindStringSubmatch(pdu.Name); m != nil {
+				fv := v.Field(f.index)
+				if f.enum != nil {
+					pdu = enumPDU(f.enum, pdu, scalarType(fv.Type()))
+				}
+				if f.hint != nil {
+					pdu = hintPDU(f.hint, pdu, scalarType(fv.Type()))
+				}
+				if fv.Kind() != reflect.Map {
+					return true, setValue(pdu, fv, joinPath(path, f.name))
+				}
+				return true, assignToMap(m[1:], f.keyFields, pdu, fv, joinPath(path, f.name))
+			}
+		case fieldTable:
+			if found, err := assignToTable(f.table, pdu, v.Field(f.index), joinPath(path, f.name), rows); found {
+				return true, err
+			}
+		case fieldStruct:
+			if found, err := f.nested.marshal(pdu, v.Field(f.index), joinPath(path, f.name), rows); found {
+				return true, err
+			}
+		case fieldPtr:
+			fv := v.Field(f.index)
+			if !fv.IsNil() {
+				if found, err := f.nested.marshal(pdu, fv.Elem(), joinPath(path, f.name), rows); found {
+					return true, err
+				}
+				continue
+			}
+			// Only allocate the nested struct once something is found for it
+			nv := reflect.New(f.nested.typ)
+			if found, err := f.nested.marshal(pdu, nv.Elem(), joinPath(path, f.name), rows); found {
+				fv.Set(nv)
+				return true, err
+			}
+		}
+	}
+	return false, nil
+}
+
+/*
+Returns the OIDs of all the oid tags in source, which must be a value of, or a pointer to, the struct type
+of the Codec.  See GetOidsFromStructTags() for details.
+*/
+func (c *Codec) Oids(source interface{}, getNested bool) []string {
+	v := reflect.ValueOf(source)
+	if v.Kind() == reflect.Ptr {
+		if v.Type().Elem() != c.typ {
+			return []string{}
+		}
+		v = v.Elem()
+	} else if v.Type() != c.typ {
+		return []string{}
+	}
+	return c.oids(v, getNested)
+}
+
+// oids does the work of Oids(), v may be invalid if the source was a nil pointer
+func (c *Codec) oids(v reflect.Value, getNested bool) []string {
+	result := make([]string, 0, len(c.fields))
+	for i := range c.fields {
+		f := &c.fields[i]
+		if len(f.oid) > 0 {
+			result = append(result, f.oid)
+		}
+		if !getNested || !v.IsValid() {
+			continue
+		}
+		switch f.kind {
+		case fieldStruct:
+			result = append(result, f.nested.oids(v.Field(f.index), true)...)
+		case fieldPtr:
+			if fv := v.Field(f.index); !fv.IsNil() {
+				result = append(result, f.nested.oids(fv.Elem(), true)...)
+			}
+		}
+	}
+	return result
+}
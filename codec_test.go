@@ -0,0 +1,145 @@
+package gosnmpHelper
+
+import (
+	"fmt"
+	snmp "github.com/gosnmp/gosnmp"
+	"reflect"
+	"testing"
+)
+
+type fdbInfo struct {
+	FdbPort   map[string]int `oidx:"\\.1\\.3\\.6\\.1\\.2\\.1\\.17\\.4\\.3\\.1\\.2\\.(.+)"`
+	FdbStatus map[string]int `oidx:"\\.1\\.3\\.6\\.1\\.2\\.1\\.17\\.4\\.3\\.1\\.3\\.(.+)"`
+}
+
+type linkedInfo struct {
+	SysDesc string `oid:".1.3.6.1.2.1.1.1.0"`
+	Next    *linkedInfo
+}
+
+func TestNewCodec(t *testing.T) {
+	c1, err := NewCodec(reflect.TypeOf(SysInfo2{}))
+	if err != nil {
+		t.Fatalf("NewCodec() err = %v", err)
+	}
+	c2, err := Compile[SysInfo2]()
+	if err != nil {
+		t.Fatalf("Compile() err = %v", err)
+	}
+	if c1 != c2 {
+		t.Errorf("codec was not cached")
+	}
+	if _, err = NewCodec(reflect.TypeOf(1)); err != ErrNotStruct {
+		t.Errorf("NewCodec() err = %v, want %v", err, ErrNotStruct)
+	}
+
+	var info SysInfo2
+	pdu := snmp.SnmpPDU{Name: ".1.3.6.1.2.1.2.2.1.8.6", Type: snmp.Integer, Value: 1}
+	if found, err := c1.Marshal(pdu, &info); !found || err != nil {
+		t.Fatalf("Marshal() = %v, %v", found, err)
+	}
+	if info.Intfs == nil || info.Intfs.IfOperStatus["6"] != 1 {
+		t.Errorf("Marshal() did not set Intfs.IfOperStatus[6]: %+v", info.Intfs)
+	}
+	if _, err := c1.Marshal(pdu, &Test1{}); err != ErrNotPointer {
+		t.Errorf("Marshal() err = %v, want %v", err, ErrNotPointer)
+	}
+	if got, want := c1.Oids(&info, true), GetOidsFromStructTags(&info, true); !reflect.DeepEqual(got, want) {
+		t.Errorf("Oids() = %v, want %v", got, want)
+	}
+
+	// A self referencing type must not recurse forever
+	var linked linkedInfo
+	if _, err := MarshalPDUToStructE(snmp.SnmpPDU{Name: ".1.3.6.1.2.1.1.1.0", Type: snmp.OctetString, Value: []byte("x")}, &linked); err != nil {
+		t.Errorf("MarshalPDUToStructE() err = %v", err)
+	}
+	if linked.SysDesc != "x" || linked.Next != nil {
+		t.Errorf("linked = %+v", linked)
+	}
+}
+
+func TestOidxPattern(t *testing.T) {
+	tests := []struct {
+		tag  reflect.StructTag
+		want string
+	}{
+		{`oidx:"\\.1\\.3\\.6\\.1\\.2\\.1\\.17\\.7\\.1\\.2\\.2\\.1\\.3\\.(\\d+)\\.([\\d.]+)"`, `\.1\.3\.6\.1\.2\.1\.17\.7\.1\.2\.2\.1\.3\.(\d+)\.([\d.]+)`},
+		// The unquoted form, which reflect.StructTag.Get() can't parse
+		{`oidx:\.1\.3\.6\.1\.2\.1\.17\.7\.1\.2\.2\.1\.3\.(\d+)\.([\d.]+)`, `\.1\.3\.6\.1\.2\.1\.17\.7\.1\.2\.2\.1\.3\.(\d+)\.([\d.]+)`},
+		{`oid:".1.3.6.1.2.1.1.1.0"`, ""},
+	}
+	for _, tt := range tests {
+		if got := oidxPattern(tt.tag); got != tt.want {
+			t.Errorf("oidxPattern(%s) = %q, want %q", tt.tag, got, tt.want)
+		}
+	}
+}
+
+// Two copies of a pair of types referring to each other, so each order of compiling starts from an empty cache
+type cycleOuter1 struct {
+	SysName string `oid:".1.3.6.1.2.1.1.5.0"`
+	Inner   cycleInner1
+}
+
+type cycleInner1 struct {
+	SysDesc string `oid:".1.3.6.1.2.1.1.1.0"`
+	Outer   *cycleOuter1
+}
+
+type cycleOuter2 struct {
+	SysName string `oid:".1.3.6.1.2.1.1.5.0"`
+	Inner   cycleInner2
+}
+
+type cycleInner2 struct {
+	SysDesc string `oid:".1.3.6.1.2.1.1.1.0"`
+	Outer   *cycleOuter2
+}
+
+func TestCodecCycleOrder(t *testing.T) {
+	// Outer first, then Inner
+	if _, err := Compile[cycleOuter1](); err != nil {
+		t.Fatalf("Compile() err = %v", err)
+	}
+	c1, err := Compile[cycleInner1]()
+	if err != nil {
+		t.Fatalf("Compile() err = %v", err)
+	}
+	// Inner first, then Outer
+	c2, err := Compile[cycleInner2]()
+	if err != nil {
+		t.Fatalf("Compile() err = %v", err)
+	}
+	if _, err := Compile[cycleOuter2](); err != nil {
+		t.Fatalf("Compile() err = %v", err)
+	}
+
+	pdu := snmp.SnmpPDU{Name: ".1.3.6.1.2.1.1.5.0", Type: snmp.OctetString, Value: []byte("router")}
+	var inner1 cycleInner1
+	var inner2 cycleInner2
+	if found, err := c1.Marshal(pdu, &inner1); !found || err != nil || inner1.Outer == nil || inner1.Outer.SysName != "router" {
+		t.Errorf("Marshal() after compiling the outer type = %v, %v, %+v", found, err, inner1)
+	}
+	if found, err := c2.Marshal(pdu, &inner2); !found || err != nil || inner2.Outer == nil || inner2.Outer.SysName != "router" {
+		t.Errorf("Marshal() after compiling the inner type = %v, %v, %+v", found, err, inner2)
+	}
+	var outer1 cycleOuter1
+	if found, err := MarshalPDUToStructE(snmp.SnmpPDU{Name: ".1.3.6.1.2.1.1.1.0", Type: snmp.OctetString, Value: []byte("x")}, &outer1); !found || err != nil || outer1.Inner.SysDesc != "x" {
+		t.Errorf("MarshalPDUToStructE() = %v, %v, %+v", found, err, outer1)
+	}
+}
+
+func BenchmarkMarshalFdb(b *testing.B) {
+	pdus := make([]snmp.SnmpPDU, 0, 1000)
+	for i := 0; i < 500; i++ {
+		mac := fmt.Sprintf("0.80.86.%d.%d.%d", i/256, i%256, i%7)
+		pdus = append(pdus,
+			snmp.SnmpPDU{Name: ".1.3.6.1.2.1.17.4.3.1.2." + mac, Type: snmp.Integer, Value: i % 48},
+			snmp.SnmpPDU{Name: ".1.3.6.1.2.1.17.4.3.1.3." + mac, Type: snmp.Integer, Value: 3})
+	}
+	b.ResetTimer()
+	for n := 0; n < b.N; n++ {
+		var info fdbInfo
+		MarshalPDUsToStruct(pdus, &info)
+	}
+}
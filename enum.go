@@ -0,0 +1,138 @@
+package gosnmpHelper
+
+import (
+	"fmt"
+	"github.com/gosnmp/gosnmp"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+//go:generate go run ./cmd/gosnmphelper gen -M mib/testdata -mib IF-MIB -enum ifAdminStatus,ifOperStatus,ifLinkUpDownTrapEnable,IANAifType,TruthValue -pkg gosnmpHelper -o enums_ifmib.go
+//go:generate go run ./cmd/gosnmphelper gen -M mib/testdata -mib ENTITY-MIB -enum PhysicalClass -pkg gosnmpHelper -o enums_entity.go
+
+/*
+Enum is implemented by named integer types holding an enumerated INTEGER, such as ifOperStatus or the
+PhysicalClass textual convention.  Struct members of such types are filled from Integer PDUs as any other
+integer, keeping values the MIB doesn't name, and also from OctetStrings holding a name such as "up", the
+net-snmp form "up(1)" or a number, as found in captures taken without -On.  For example:
+
+	type Intf struct {
+		Index  string       `oidcol:"index"`
+		Status IfOperStatus `oidcol:"8"`
+	}
+
+See IfOperStatus and the other types generated from the common IF-MIB and ENTITY-MIB enumerations.
+"gosnmphelper gen -enum" generates the same for other MIBs.
+*/
+type Enum interface {
+	fmt.Stringer
+	// EnumNames returns the names of the values defined by the MIB.  The map must not be modified.
+	EnumNames() map[int]string
+}
+
+var typeEnum = reflect.TypeOf((*Enum)(nil)).Elem()
+
+// Enum tags already parsed, keyed by the tag
+var enumTags sync.Map
+
+/*
+ParseEnum returns the value of an enumeration given its name, such as "up", the name and value as written
+by net-snmp, such as "up(1)", or a number.  An error wrapping ErrUnknownEnum is returned for a name which
+is not in names.  For example:
+
+	status, err := ParseEnum(IfOperStatus(0).EnumNames(), "lowerLayerDown")
+*/
+func ParseEnum(names map[int]string, s string) (int, error) {
+	s = strings.TrimSpace(s)
+	if open := strings.IndexByte(s, '('); open > 0 && strings.HasSuffix(s, ")") {
+		s = s[open+1 : len(s)-1]
+	}
+	if v, err := strconv.Atoi(s); err == nil {
+		return v, nil
+	}
+	for v, name := range names {
+		if name == s {
+			return v, nil
+		}
+	}
+	return 0, fmt.Errorf("%w %q", ErrUnknownEnum, s)
+}
+
+// parseEnumTag parses an enum struct tag such as `enum:"up=1,down=2,testing=3"`.  The names are cached, so
+// the map returned must not be modified.
+func parseEnumTag(tag string) (map[int]string, error) {
+	if names, ok := enumTags.Load(tag); ok {
+		return names.(map[int]string), nil
+	}
+	names := make(map[int]string)
+	for _, item := range strings.Split(tag, ",") {
+		name, value, _ := strings.Cut(item, "=")
+		name = strings.TrimSpace(name)
+		v, err := strconv.Atoi(strings.TrimSpace(value))
+		if err != nil || len(name) == 0 {
+			return nil, fmt.Errorf("%w %q", ErrInvalidEnum, tag)
+		}
+		names[v] = name
+	}
+	actual, _ := enumTags.LoadOrStore(tag, names)
+	return actual.(map[int]string), nil
+}
+
+// enumPDU prepares a PDU for a member of type t which takes the named values.  For string members an
+// Integer value is replaced by its name.  For integer members an OctetString holding a name is replaced by
+// its value.  Values without a name are left as they are, so they are stored as a number.
+func enumPDU(names map[int]string, pdu gosnmp.SnmpPDU, t reflect.Type) gosnmp.SnmpPDU {
+	switch t.Kind() {
+	case reflect.String:
+		if pdu.Type == gosnmp.Integer {
+			if name, ok := names[int(GetAsInt64(pdu))]; ok {
+				pdu.Type, pdu.Value = gosnmp.OctetString, []byte(name)
+			}
+		}
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		if pdu.Type == gosnmp.OctetString {
+			if v, err := ParseEnum(names, GetAsString(pdu)); err == nil {
+				pdu.Type, pdu.Value = gosnmp.Integer, v
+			}
+		}
+	}
+	return pdu
+}
+
+// typeEnumNames returns the names for a type implementing Enum, or nil for other types
+func typeEnumNames(t reflect.Type) map[int]string {
+	if !t.Implements(typeEnum) {
+		return nil
+	}
+	return reflect.Zero(t).Interface().(Enum).EnumNames()
+}
+
+// scalarType returns the type of the values held by a member, which may be a map of them
+func scalarType(t reflect.Type) reflect.Type {
+	for t.Kind() == reflect.Map {
+		t = t.Elem()
+	}
+	return t
+}
+
+// enumValue returns the value of a string member with an enum tag as an integer, so that it's sent as an
+// Integer PDU.  Other members are returned as they are.  False is returned if the enum tag is invalid.
+func enumValue(fInfo reflect.StructField, v reflect.Value) (reflect.Value, bool) {
+	tag := fInfo.Tag.Get("enum")
+	if len(tag) == 0 {
+		return v, true
+	}
+	names, err := parseEnumTag(tag)
+	if err != nil {
+		return v, false
+	}
+	if v.Kind() == reflect.String {
+		if i, err := ParseEnum(names, v.String()); err == nil {
+			return reflect.ValueOf(i), true
+		}
+	}
+	return v, true
+}
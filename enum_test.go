@@ -0,0 +1,126 @@
+package gosnmpHelper
+
+import (
+	"errors"
+	snmp "github.com/gosnmp/gosnmp"
+	"reflect"
+	"testing"
+)
+
+type enumRow struct {
+	Index int           `oidcol:"index"`
+	Class PhysicalClass `oidcol:"5"`
+	Name  string        `oidcol:"7"`
+	FRU   string        `oidcol:"16" enum:"true=1,false=2"`
+}
+
+type enumInfo struct {
+	OperStatus  IfOperStatus          `oid:".1.3.6.1.2.1.2.2.1.8.1"`
+	AdminStatus string                `oid:".1.3.6.1.2.1.2.2.1.7.1" enum:"up=1,down=2,testing=3"`
+	Trap        int                   `oid:".1.3.6.1.2.1.31.1.1.1.14.1" enum:"enabled=1,disabled=2"`
+	Types       map[string]IANAifType `oidx:"IF-MIB::ifType.(\\d+)"`
+	Admin       map[string]string     `oidx:"IF-MIB::ifAdminStatus.(\\d+)" enum:"up=1,down=2,testing=3"`
+	Entities    map[string]enumRow    `oidtable:".1.3.6.1.2.1.47.1.1.1.1"`
+	Promiscuous map[string]TruthValue `oidx:"IF-MIB::ifPromiscuousMode.(\\d+)"`
+}
+
+type badEnumInfo struct {
+	Status string `oid:".1.3.6.1.2.1.2.2.1.8.1" enum:"up=1,down"`
+}
+
+func TestEnum(t *testing.T) {
+	pdus := []snmp.SnmpPDU{
+		{Name: ".1.3.6.1.2.1.2.2.1.8.1", Type: snmp.Integer, Value: 7},
+		{Name: ".1.3.6.1.2.1.2.2.1.7.1", Type: snmp.Integer, Value: 2},
+		{Name: ".1.3.6.1.2.1.31.1.1.1.14.1", Type: snmp.OctetString, Value: []byte("disabled(2)")},
+		{Name: ".1.3.6.1.2.1.2.2.1.3.1", Type: snmp.Integer, Value: 24},
+		{Name: ".1.3.6.1.2.1.2.2.1.3.2", Type: snmp.OctetString, Value: []byte("ethernetCsmacd")},
+		{Name: ".1.3.6.1.2.1.2.2.1.3.3", Type: snmp.Integer, Value: 9999},
+		{Name: ".1.3.6.1.2.1.2.2.1.7.2", Type: snmp.Integer, Value: 1},
+		{Name: ".1.3.6.1.2.1.2.2.1.7.3", Type: snmp.Integer, Value: 99},
+		{Name: ".1.3.6.1.2.1.47.1.1.1.1.5.1", Type: snmp.Integer, Value: 3},
+		{Name: ".1.3.6.1.2.1.47.1.1.1.1.7.1", Type: snmp.OctetString, Value: []byte("Chassis")},
+		{Name: ".1.3.6.1.2.1.47.1.1.1.1.16.1", Type: snmp.Integer, Value: 2},
+		{Name: ".1.3.6.1.2.1.47.1.1.1.1.5.2", Type: snmp.OctetString, Value: []byte("cpu(12)")},
+		{Name: ".1.3.6.1.2.1.47.1.1.1.1.16.2", Type: snmp.Integer, Value: 1},
+		{Name: ".1.3.6.1.2.1.31.1.1.1.16.2", Type: snmp.Integer, Value: 1},
+	}
+	var info enumInfo
+	if err := MarshalPDUsToStructE(pdus, &info); err != nil {
+		t.Fatalf("MarshalPDUsToStructE() err = %v", err)
+	}
+	want := enumInfo{
+		OperStatus:  IfOperStatusLowerLayerDown,
+		AdminStatus: "down",
+		Trap:        2,
+		Types:       map[string]IANAifType{"1": IANAifTypeSoftwareLoopback, "2": IANAifTypeEthernetCsmacd, "3": 9999},
+		Admin:       map[string]string{"2": "up", "3": "99"},
+		Entities: map[string]enumRow{
+			"1": {Index: 1, Class: PhysicalClassChassis, Name: "Chassis", FRU: "false"},
+			"2": {Index: 2, Class: PhysicalClassCpu, FRU: "true"},
+		},
+		Promiscuous: map[string]TruthValue{"2": TruthValueTrue},
+	}
+	if !reflect.DeepEqual(info, want) {
+		t.Errorf("got %+v\nwant %+v", info, want)
+	}
+	if s := info.OperStatus.String(); s != "lowerLayerDown" {
+		t.Errorf("String() = %s", s)
+	}
+	// Values the MIB doesn't name are kept
+	if s := info.Types["3"].String(); s != "9999" {
+		t.Errorf("String() of unknown value = %s", s)
+	}
+
+	// Names which aren't in the enumeration are a conversion error
+	_, err := MarshalPDUToStructE(snmp.SnmpPDU{Name: ".1.3.6.1.2.1.2.2.1.8.1", Type: snmp.OctetString, Value: []byte("sideways")}, &info)
+	if !errors.Is(err, ErrConversion) {
+		t.Errorf("MarshalPDUToStructE() err = %v, want ErrConversion", err)
+	}
+	if _, err := NewCodec(reflect.TypeOf(badEnumInfo{})); !errors.Is(err, ErrInvalidEnum) {
+		t.Errorf("NewCodec() err = %v, want ErrInvalidEnum", err)
+	}
+
+	out := MarshalStructToPDUs(enumInfo{OperStatus: IfOperStatusUp, AdminStatus: "testing", Trap: 1})
+	if len(out) != 3 || out[0].Type != snmp.Integer || out[0].Value != 1 ||
+		out[1].Type != snmp.Integer || out[1].Value != 3 || out[2].Value != 1 {
+		t.Errorf("MarshalStructToPDUs() = %v", out)
+	}
+	// As NewCodec() rejects the invalid enum tag, the member isn't sent
+	if out := MarshalStructToPDUs(badEnumInfo{Status: "up"}); len(out) != 0 {
+		t.Errorf("MarshalStructToPDUs() with invalid enum tag = %v", out)
+	}
+	// The tag is parsed once, for NewCodec() and MarshalStructToPDUs() alike
+	names, _ := parseEnumTag("up=1,down=2,testing=3")
+	if again, _ := parseEnumTag("up=1,down=2,testing=3"); reflect.ValueOf(again).Pointer() != reflect.ValueOf(names).Pointer() {
+		t.Errorf("parseEnumTag() parsed the tag again")
+	}
+}
+
+func TestParseEnum(t *testing.T) {
+	names := IfOperStatus(0).EnumNames()
+	tests := []struct {
+		s       string
+		want    int
+		wantErr error
+	}{
+		{s: "up", want: 1},
+		{s: " lowerLayerDown ", want: 7},
+		{s: "dormant(5)", want: 5},
+		{s: "42", want: 42},
+		{s: "-1", want: -1},
+		{s: "Up", wantErr: ErrUnknownEnum},
+		{s: "", wantErr: ErrUnknownEnum},
+	}
+	for _, tt := range tests {
+		t.Run(tt.s, func(t *testing.T) {
+			got, err := ParseEnum(names, tt.s)
+			if !errors.Is(err, tt.wantErr) || (tt.wantErr == nil && err != nil) {
+				t.Fatalf("ParseEnum() err = %v, want %v", err, tt.wantErr)
+			}
+			if got != tt.want {
+				t.Errorf("ParseEnum() = %d, want %d", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,60 @@
+// Code generated by gosnmphelper gen -M mib/testdata -mib ENTITY-MIB -enum PhysicalClass -pkg gosnmpHelper -o enums_entity.go; DO NOT EDIT.
+
+package gosnmpHelper
+
+import (
+	"strconv"
+)
+
+// PhysicalClass is the enumeration of ENTITY-MIB::PhysicalClass
+type PhysicalClass int
+
+// The values of PhysicalClass
+const (
+	PhysicalClassOther        PhysicalClass = 1
+	PhysicalClassUnknown      PhysicalClass = 2
+	PhysicalClassChassis      PhysicalClass = 3
+	PhysicalClassBackplane    PhysicalClass = 4
+	PhysicalClassContainer    PhysicalClass = 5
+	PhysicalClassPowerSupply  PhysicalClass = 6
+	PhysicalClassFan          PhysicalClass = 7
+	PhysicalClassSensor       PhysicalClass = 8
+	PhysicalClassModule       PhysicalClass = 9
+	PhysicalClassPort         PhysicalClass = 10
+	PhysicalClassStack        PhysicalClass = 11
+	PhysicalClassCpu          PhysicalClass = 12
+	PhysicalClassEnergyObject PhysicalClass = 13
+	PhysicalClassBattery      PhysicalClass = 14
+	PhysicalClassStorageDrive PhysicalClass = 15
+)
+
+var physicalClassNames = map[int]string{
+	1:  "other",
+	2:  "unknown",
+	3:  "chassis",
+	4:  "backplane",
+	5:  "container",
+	6:  "powerSupply",
+	7:  "fan",
+	8:  "sensor",
+	9:  "module",
+	10: "port",
+	11: "stack",
+	12: "cpu",
+	13: "energyObject",
+	14: "battery",
+	15: "storageDrive",
+}
+
+// String returns the name of the value, or the number for a value the MIB doesn't name
+func (e PhysicalClass) String() string {
+	if name, ok := physicalClassNames[int(e)]; ok {
+		return name
+	}
+	return strconv.Itoa(int(e))
+}
+
+// EnumNames returns the names of the values defined by the MIB
+func (PhysicalClass) EnumNames() map[int]string {
+	return physicalClassNames
+}
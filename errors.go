@@ -0,0 +1,74 @@
+package gosnmpHelper
+
+import (
+	"errors"
+	"fmt"
+)
+
+var (
+	// ErrNotPointer is returned when the destination of a marshal is not a non-nil pointer to a struct
+	ErrNotPointer = errors.New("dest must be a pointer to a struct")
+	// ErrInvalidOID is returned when a string cannot be parsed as an OID
+	ErrInvalidOID = errors.New("invalid OID")
+	// ErrNotStruct is returned when a Codec is requested for a type which is not a struct
+	ErrNotStruct = errors.New("type must be a struct")
+	// ErrUnknownName is returned when a symbolic OID such as "IF-MIB::ifDescr" is not in the symbol table
+	ErrUnknownName = errors.New("unknown MIB name")
+	// ErrUnsupportedField is returned when a PDU matches a struct member whose type cannot hold PDU values
+	ErrUnsupportedField = errors.New("unsupported struct member type")
+	// ErrConversion is returned when a PDU value cannot be converted to the type of the matching struct member
+	ErrConversion = errors.New("unable to convert PDU value")
+	// ErrInvalidEnum is returned when an enum struct tag is not a list of name=value pairs
+	ErrInvalidEnum = errors.New("invalid enum tag")
+	// ErrUnknownEnum is returned when a name is not one of the values of an enumeration
+	ErrUnknownEnum = errors.New("unknown enumeration name")
+	// ErrInvalidHint is returned when a DISPLAY-HINT cannot be parsed or does not suit the PDU value
+	ErrInvalidHint = errors.New("invalid display hint")
+)
+
+var (
+	// ErrNilValue is returned by the checked getters when the PDU has no value
+	ErrNilValue = errors.New("PDU value is nil")
+	// ErrNotNumeric is returned by the checked getters when the PDU value is not a number or numeric string
+	ErrNotNumeric = errors.New("PDU value is not numeric")
+	// ErrOverflow is returned by the checked getters when the PDU value does not fit in the requested type
+	ErrOverflow = errors.New("PDU value overflows type")
+	// ErrSignLoss is returned by the checked getters when a negative PDU value is requested as unsigned
+	ErrSignLoss = errors.New("negative PDU value requested as unsigned")
+	// ErrParse is returned by the checked getters when an OctetString PDU value cannot be parsed as a number
+	ErrParse = errors.New("unable to parse PDU value as a number")
+)
+
+var (
+	// ErrNoSuchObject is returned when an agent has no such object for a requested OID
+	ErrNoSuchObject = errors.New("no such object")
+	// ErrNoSuchInstance is returned when an agent has no such instance for a requested OID
+	ErrNoSuchInstance = errors.New("no such instance")
+	// ErrEndOfMibView is returned when a request goes beyond the end of the agent's MIB view
+	ErrEndOfMibView = errors.New("end of MIB view")
+	// ErrNoWalkRoot is returned when the subtree to walk for an oidx member cannot be derived from its pattern
+	ErrNoWalkRoot = errors.New("cannot derive walk root from oidx pattern, add a walk tag")
+	// ErrInvalidSnapshot is returned when a PDU cannot be written to, or read from, a snapshot
+	ErrInvalidSnapshot = errors.New("invalid snapshot record")
+	// ErrNotCounter is returned when a rate is requested for a PDU which is not a Counter32 or Counter64
+	ErrNotCounter = errors.New("PDU is not a counter")
+	// ErrNoUptime is returned when rates are requested for PDUs which don't include sysUpTime.0
+	ErrNoUptime = errors.New("sysUpTime.0 missing from PDUs")
+	// ErrInvalidInterval is returned when a Poller is started without a positive interval
+	ErrInvalidInterval = errors.New("poll interval must be positive")
+)
+
+// FieldError records a failure to store a PDU value into a struct member, or to get a value for one
+type FieldError struct {
+	Field string // Path to the struct member, such as "Intfs.IfOperStatus[6]"
+	OID   string // Name of the PDU
+	Err   error
+}
+
+func (e *FieldError) Error() string {
+	return fmt.Sprintf("%s (%s): %v", e.Field, e.OID, e.Err)
+}
+
+func (e *FieldError) Unwrap() error {
+	return e.Err
+}
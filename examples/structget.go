@@ -1,6 +1,7 @@
 package main
 
 import (
+	"context"
 	"fmt"
 	"github.com/gosnmp/gosnmp"
 	"github.com/jjcinaz/gosnmpHelper"
@@ -16,20 +17,15 @@ type BasicInfo struct {
 }
 
 func main() {
-	var (
-		info   BasicInfo
-		result *gosnmp.SnmpPacket
-	)
+	var info BasicInfo
 	gosnmp.Default.Target = "192.168.91.1"
 	err := gosnmp.Default.Connect()
 	if err != nil {
 		log.Fatalf("Connect() err: %v", err)
 	}
 	defer gosnmp.Default.Conn.Close()
-	result, err = gosnmp.Default.Get(gosnmpHelper.GetOidsFromStructTags(&info, false))
-	if err != nil {
+	if err = gosnmpHelper.Fetch(context.Background(), gosnmp.Default, &info); err != nil {
 		log.Fatalf("snmp failure %s", err)
 	}
-	gosnmpHelper.MarshalPDUsToStruct(result.Variables, &info)
 	fmt.Printf("%#v\n", info)
 }
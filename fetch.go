@@ -0,0 +1,172 @@
+package gosnmpHelper
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/gosnmp/gosnmp"
+	"reflect"
+)
+
+/*
+Fetch gets the values for all the oid tags in dest, which must be a pointer to a struct, and marshals them
+into the struct.  This replaces the usual sequence of GetOidsFromStructTags(), client.Get() and
+MarshalPDUsToStruct().  For example:
+
+	var info struct {
+		SysDesc   string        `oid:".1.3.6.1.2.1.1.1.0"`
+		SysUpTime time.Duration `oid:".1.3.6.1.2.1.1.3.0"`
+		SysName   string        `oid:".1.3.6.1.2.1.1.5.0"`
+	}
+	err := Fetch(ctx, client, &info)
+
+The client must already be connected.  The OIDs are requested in as many Gets as needed to keep each one
+within client.MaxOids, with each OID requested once even if several members have it.  The context is used as the client's Context for the duration of the call, so the
+client must not be used by other goroutines at the same time.
+
+As with GetOidsFromStructTags(), nested structs are included but pointers to nested structs must be non-nil
+else they are skipped.  Members with oidx or oidtable tags are not fetched; see WalkInto() for those.
+
+Values which the agent reports as noSuchObject, noSuchInstance or endOfMibView do not stop the other values
+from being stored.  Nor do values which cannot be marshaled into their member.  All such problems are joined
+into the returned error, and can be tested for with errors.Is() using ErrNoSuchObject, ErrNoSuchInstance,
+ErrEndOfMibView, or the errors described for MarshalPDUToStructE().  Values the agent doesn't have are
+reported as a *FieldError naming the member, as for values which cannot be marshaled.  For SNMPv1 agents,
+which fail the whole request with noSuchName, the offending OID is reported as ErrNoSuchObject and the
+request is retried without it.
+
+Unlike GetOidsFromStructTags(), which skips members whose tags can't be used, Fetch returns the error from
+NewCodec() before any request is made.
+*/
+func Fetch(ctx context.Context, client *gosnmp.GoSNMP, dest interface{}) error {
+	errs, err := fetch(ctx, client, dest)
+	return errors.Join(append(errs, err)...)
+}
+
+// fetch does the work of Fetch(), returning the errors for values which couldn't be got separately from any
+// error which stopped it, such as a failed request
+func fetch(ctx context.Context, client *gosnmp.GoSNMP, dest interface{}) ([]error, error) {
+	destT := reflect.TypeOf(dest)
+	if destT == nil || destT.Kind() != reflect.Ptr || destT.Elem().Kind() != reflect.Struct || reflect.ValueOf(dest).IsNil() {
+		return nil, ErrNotPointer
+	}
+	c, err := NewCodec(destT)
+	if err != nil {
+		return nil, err
+	}
+	if ctx != nil {
+		saved := client.Context
+		client.Context = ctx
+		defer func() { client.Context = saved }()
+	}
+	oids := uniqueOids(c.Oids(dest, true))
+	maxOids := client.MaxOids
+	if maxOids <= 0 {
+		maxOids = gosnmp.MaxOids
+	}
+	var errs []error
+	for start := 0; start < len(oids); start += maxOids {
+		end := start + maxOids
+		if end > len(oids) {
+			end = len(oids)
+		}
+		pdus, err := getAll(client, c, oids[start:end], &errs)
+		if err != nil {
+			// The request itself failed, so there's no point continuing
+			return errs, err
+		}
+		for _, pdu := range pdus {
+			if err = varbindException(pdu.Type); err != nil {
+				errs = append(errs, c.missing(pdu.Name, err))
+				continue
+			}
+			if _, err = c.Marshal(pdu, dest); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+	return errs, nil
+}
+
+// uniqueOids removes repeated OIDs, such as from members with the same oid tag, keeping the first of each.
+// OIDs are compared in canonical form, so ".1.3.6" repeats "1.3.6".
+func uniqueOids(oids []string) []string {
+	seen := make(map[string]bool, len(oids))
+	unique := oids[:0]
+	for _, oid := range oids {
+		if canon := canonicalOID(oid); !seen[canon] {
+			seen[canon] = true
+			unique = append(unique, oid)
+		}
+	}
+	return unique
+}
+
+// getAll issues a Get for the OIDs of members of the Codec.  If an SNMPv1 agent rejects one of the OIDs with
+// noSuchName, the error for that OID is added to errs and the Get is repeated without it.
+func getAll(client *gosnmp.GoSNMP, c *Codec, oids []string, errs *[]error) ([]gosnmp.SnmpPDU, error) {
+	for len(oids) > 0 {
+		result, err := client.Get(oids)
+		if err != nil {
+			return nil, err
+		}
+		switch {
+		case result.Error == gosnmp.NoError:
+			return result.Variables, nil
+		case result.Error == gosnmp.NoSuchName && result.ErrorIndex > 0 && int(result.ErrorIndex) <= len(oids):
+			bad := int(result.ErrorIndex) - 1
+			*errs = append(*errs, c.missing(oids[bad], ErrNoSuchObject))
+			oids = append(append(make([]string, 0, len(oids)-1), oids[:bad]...), oids[bad+1:]...)
+		default:
+			return nil, fmt.Errorf("get failed: %v (index %d)", result.Error, result.ErrorIndex)
+		}
+	}
+	return nil, nil
+}
+
+// checkVarbind returns an error for the exception values an SNMPv2 agent uses in place of a value
+func checkVarbind(pdu gosnmp.SnmpPDU) error {
+	if err := varbindException(pdu.Type); err != nil {
+		return fmt.Errorf("%s: %w", pdu.Name, err)
+	}
+	return nil
+}
+
+// varbindException returns ErrNoSuchObject, ErrNoSuchInstance or ErrEndOfMibView for the exception value
+// types, or nil for any other type
+func varbindException(t gosnmp.Asn1BER) error {
+	switch t {
+	case gosnmp.NoSuchObject:
+		return ErrNoSuchObject
+	case gosnmp.NoSuchInstance:
+		return ErrNoSuchInstance
+	case gosnmp.EndOfMibView:
+		return ErrEndOfMibView
+	}
+	return nil
+}
+
+// missing returns the error for an OID with an oid tag in the Codec which the agent has no value for, naming
+// the member it was requested for
+func (c *Codec) missing(oid string, err error) error {
+	return &FieldError{Field: c.oidField(canonicalOID(oid), ""), OID: oid, Err: err}
+}
+
+// oidField returns the path to the member with the oid tag for the canonical OID, or "" if there isn't one.
+// The path is that of the Codec's struct within the outermost struct.
+func (c *Codec) oidField(oid string, path string) string {
+	for i := range c.fields {
+		f := &c.fields[i]
+		switch f.kind {
+		case fieldOid:
+			if f.match == oid {
+				return joinPath(path, f.name)
+			}
+		case fieldStruct, fieldPtr:
+			if p := f.nested.oidField(oid, joinPath(path, f.name)); p != "" {
+				return p
+			}
+		}
+	}
+	return ""
+}
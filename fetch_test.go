@@ -0,0 +1,125 @@
+package gosnmpHelper
+
+import (
+	"context"
+	"errors"
+	snmp "github.com/gosnmp/gosnmp"
+	"github.com/jjcinaz/gosnmpHelper/snmptest"
+	"testing"
+	"time"
+)
+
+type fetchInfo struct {
+	SysDesc   string        `oid:".1.3.6.1.2.1.1.1.0"`
+	SysUpTime time.Duration `oid:".1.3.6.1.2.1.1.3.0"`
+	SysName   string        `oid:".1.3.6.1.2.1.1.5.0"`
+	Services  int           `oid:".1.3.6.1.2.1.1.7.0"`
+	Location  string        `oid:".1.3.6.1.2.1.1.6.0"`
+	Missing   string        `oid:".1.3.6.1.2.1.1.99.0"`
+	Nested    struct {
+		IfNumber int `oid:".1.3.6.1.2.1.2.1.0"`
+	}
+}
+
+func TestFetch(t *testing.T) {
+	agent, err := snmptest.NewAgentFromWalkFile("testdata/router.walk")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer agent.Close()
+	client := agent.Client()
+
+	tests := []struct {
+		name     string
+		version  snmp.SnmpVersion
+		maxOids  int
+		requests int
+	}{
+		{name: "SNMPv2c", version: snmp.Version2c, maxOids: snmp.MaxOids, requests: 1},
+		{name: "SNMPv2c chunked", version: snmp.Version2c, maxOids: 3, requests: 3},
+		// The request with the missing OID is repeated without it
+		{name: "SNMPv1", version: snmp.Version1, maxOids: 3, requests: 4},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			client.Version, client.MaxOids = tt.version, tt.maxOids
+			before := agent.Requests()
+			var info fetchInfo
+			err := Fetch(context.Background(), client, &info)
+			var fe *FieldError
+			if !errors.Is(err, ErrNoSuchObject) || !errors.As(err, &fe) || fe.Field != "Missing" {
+				t.Errorf("Fetch() err = %v, want ErrNoSuchObject for Missing", err)
+			}
+			if info.SysDesc != "Linux router 5.10.0-21-amd64 #1 SMP x86_64" || info.SysUpTime != 76543210*time.Millisecond ||
+				info.SysName != "router" || info.Services != 72 || info.Location != "Rack 12" || info.Nested.IfNumber != 3 {
+				t.Errorf("Fetch() = %+v", info)
+			}
+			if got := agent.Requests() - before; got != tt.requests {
+				t.Errorf("Fetch() made %d requests, want %d", got, tt.requests)
+			}
+		})
+	}
+	if err = Fetch(context.Background(), client, fetchInfo{}); !errors.Is(err, ErrNotPointer) {
+		t.Errorf("Fetch() err = %v, want ErrNotPointer", err)
+	}
+}
+
+type badFetchInfo struct {
+	SysName string `oid:"NO-SUCH-MIB::foo.0"`
+}
+
+type dupFetchInfo struct {
+	SysName  string `oid:".1.3.6.1.2.1.1.5.0"`
+	Name     string `oid:".1.3.6.1.2.1.1.5.0"`
+	SysDesc  string `oid:".1.3.6.1.2.1.1.1.0"`
+	Hostname string `oid:"1.3.6.1.2.1.1.5.0"`
+}
+
+func TestFetchDuplicates(t *testing.T) {
+	agent, err := snmptest.NewAgentFromWalkFile("testdata/router.walk")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer agent.Close()
+	client := agent.Client()
+	client.MaxOids = 2
+
+	var info dupFetchInfo
+	if err := Fetch(context.Background(), client, &info); err != nil {
+		t.Fatalf("Fetch() err = %v", err)
+	}
+	if info.SysName != "router" || info.SysDesc == "" {
+		t.Errorf("Fetch() = %+v", info)
+	}
+	// The two distinct OIDs fit in one Get
+	if got := agent.Requests(); got != 1 {
+		t.Errorf("Fetch() made %d requests, want 1", got)
+	}
+}
+
+func TestFetchBadTag(t *testing.T) {
+	agent := snmptest.NewAgent(nil)
+	defer agent.Close()
+	client := agent.Client()
+
+	var info badFetchInfo
+	if err := Fetch(context.Background(), client, &info); !errors.Is(err, ErrUnknownName) {
+		t.Errorf("Fetch() err = %v, want ErrUnknownName", err)
+	}
+	if got := agent.Requests(); got != 0 {
+		t.Errorf("Fetch() made %d requests, want 0", got)
+	}
+}
+
+func TestFetchTimeout(t *testing.T) {
+	agent := snmptest.NewAgent(nil)
+	client := agent.Client()
+	agent.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	var info fetchInfo
+	if err := Fetch(ctx, client, &info); err == nil {
+		t.Errorf("Fetch() from stopped agent succeeded")
+	}
+}
@@ -0,0 +1,147 @@
+package gosnmpHelper
+
+import (
+	"context"
+	"errors"
+	"github.com/gosnmp/gosnmp"
+	"reflect"
+	"sync"
+	"time"
+)
+
+// TargetConfig gives the address of a device and the settings for reaching it
+type TargetConfig struct {
+	Target    string // host name or IP address
+	Port      uint16 // defaults to 161
+	Community string // for SNMPv1 and SNMPv2c
+	Version   gosnmp.SnmpVersion
+	Timeout   time.Duration // for each request, defaults to 2 seconds
+	Retries   int
+	MaxOids   int // the most OIDs in one Get, defaults to gosnmp.MaxOids
+
+	// For SNMPv3, where SecurityParameters is normally a *gosnmp.UsmSecurityParameters
+	MsgFlags           gosnmp.SnmpV3MsgFlags
+	SecurityParameters gosnmp.SnmpV3SecurityParameters
+	ContextName        string
+}
+
+// Client returns a client, not yet connected, for the target
+func (tc TargetConfig) Client() *gosnmp.GoSNMP {
+	client := &gosnmp.GoSNMP{
+		Target:             tc.Target,
+		Port:               tc.Port,
+		Community:          tc.Community,
+		Version:            tc.Version,
+		Timeout:            tc.Timeout,
+		Retries:            tc.Retries,
+		MaxOids:            tc.MaxOids,
+		MsgFlags:           tc.MsgFlags,
+		SecurityParameters: tc.SecurityParameters,
+		ContextName:        tc.ContextName,
+	}
+	if client.Port == 0 {
+		client.Port = 161
+	}
+	if client.Timeout <= 0 {
+		client.Timeout = 2 * time.Second
+	}
+	if client.MaxOids <= 0 {
+		client.MaxOids = gosnmp.MaxOids
+	}
+	if tc.SecurityParameters != nil {
+		client.SecurityModel = gosnmp.UserSecurityModel
+	}
+	return client
+}
+
+// FetchResult is the outcome of fetching from one target with FetchMany()
+type FetchResult struct {
+	Target   TargetConfig
+	Dest     any // the value from newDest, filled in as far as the fetch got
+	Err      error
+	Duration time.Duration
+}
+
+/*
+FetchMany connects to each target, fills a new destination from newDest with Fetch() and WalkInto(), and
+disconnects.  Up to workers targets are handled at once; fewer than 1 is taken as 1.  newDest must return a
+pointer to a struct and is called once for each target, and once more to check the type.  For example:
+
+	results := FetchMany(ctx, targets, func() any { return new(Inventory) }, 64)
+	for _, result := range results {
+		if result.Err != nil {
+			log.Printf("%s: %v", result.Target.Target, result.Err)
+			continue
+		}
+		inv := result.Dest.(*Inventory)
+		...
+	}
+
+The results are in the same order as targets.  A failure to connect, or a problem with any of the values,
+is reported in the target's Err as described for Fetch() and WalkInto().  If the Gets fail, such as for a
+target which doesn't respond, its walks are not attempted.  Targets not yet started when ctx is done are
+given its error.
+
+The Codec for the type returned by newDest is built before any target is contacted.  If that fails, such as
+for a tag naming an unknown MIB object, every result is given the error and nothing is fetched.
+*/
+func FetchMany(ctx context.Context, targets []TargetConfig, newDest func() any, workers int) []FetchResult {
+	if workers < 1 {
+		workers = 1
+	}
+	results := make([]FetchResult, len(targets))
+	if len(targets) == 0 {
+		return results
+	}
+	if _, err := NewCodec(reflect.TypeOf(newDest())); err != nil {
+		for i := range targets {
+			results[i] = FetchResult{Target: targets[i], Err: err}
+		}
+		return results
+	}
+	jobs := make(chan int)
+	var wg sync.WaitGroup
+	for w := 0; w < workers && w < len(targets); w++ {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			for i := range jobs {
+				results[i] = fetchOne(ctx, targets[i], newDest())
+			}
+		}()
+	}
+	for i := range targets {
+		select {
+		case jobs <- i:
+		case <-ctx.Done():
+			results[i] = FetchResult{Target: targets[i], Err: ctx.Err()}
+		}
+	}
+	close(jobs)
+	wg.Wait()
+	return results
+}
+
+// fetchOne connects to a target and fills dest from it
+func fetchOne(ctx context.Context, target TargetConfig, dest any) FetchResult {
+	start := time.Now()
+	err := ctx.Err()
+	if err == nil {
+		client := target.Client()
+		if err = client.Connect(); err == nil {
+			err = fetchAndWalk(ctx, client, dest)
+			client.Conn.Close()
+		}
+	}
+	return FetchResult{Target: target, Dest: dest, Err: err, Duration: time.Since(start)}
+}
+
+// fetchAndWalk fills dest with both Fetch() and WalkInto().  The walks are skipped if Fetch() couldn't reach
+// the agent, or ctx is done, since they would only fail the same way.
+func fetchAndWalk(ctx context.Context, client *gosnmp.GoSNMP, dest any) error {
+	errs, err := fetch(ctx, client, dest)
+	if err != nil || ctx.Err() != nil {
+		return errors.Join(append(errs, err)...)
+	}
+	return errors.Join(append(errs, WalkInto(ctx, client, dest))...)
+}
@@ -0,0 +1,107 @@
+package gosnmpHelper
+
+import (
+	"context"
+	"errors"
+	snmp "github.com/gosnmp/gosnmp"
+	"github.com/jjcinaz/gosnmpHelper/snmptest"
+	"strings"
+	"testing"
+	"time"
+)
+
+func agentTarget(agent *snmptest.Agent) TargetConfig {
+	return TargetConfig{
+		Target:    agent.Addr().IP.String(),
+		Port:      uint16(agent.Addr().Port),
+		Community: "public",
+		Version:   snmp.Version2c,
+		Timeout:   time.Second,
+	}
+}
+
+func TestFetchMany(t *testing.T) {
+	router, err := snmptest.NewAgentFromWalkFile("testdata/router.walk")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer router.Close()
+	other := snmptest.NewAgentFromMap(map[string]interface{}{".1.3.6.1.2.1.1.5.0": "switch"})
+	defer other.Close()
+	targets := []TargetConfig{
+		agentTarget(router),
+		{Target: "256.256.256.256"},
+		agentTarget(other),
+		agentTarget(router),
+	}
+	results := FetchMany(context.Background(), targets, func() any { return new(pollInfo) }, 2)
+	if len(results) != len(targets) {
+		t.Fatalf("FetchMany() gave %d results", len(results))
+	}
+	wantNames := []string{"router", "", "switch", "router"}
+	for i, result := range results {
+		if result.Target != targets[i] {
+			t.Errorf("result %d is for %+v", i, result.Target)
+		}
+		if (result.Err != nil) != (i == 1) {
+			t.Errorf("result %d err = %v", i, result.Err)
+		}
+		info := result.Dest.(*pollInfo)
+		if info.SysName != wantNames[i] {
+			t.Errorf("result %d SysName = %q, want %q", i, info.SysName, wantNames[i])
+		}
+		if i != 1 && result.Duration <= 0 {
+			t.Errorf("result %d has no duration", i)
+		}
+	}
+	if results[0].Dest.(*pollInfo).IfDescr["6"] != "eth1" {
+		t.Errorf("router IfDescr = %v", results[0].Dest.(*pollInfo).IfDescr)
+	}
+
+	ctx, cancel := context.WithCancel(context.Background())
+	cancel()
+	for _, result := range FetchMany(ctx, targets, func() any { return new(pollInfo) }, 0) {
+		if !errors.Is(result.Err, context.Canceled) {
+			t.Errorf("FetchMany() with cancelled context err = %v", result.Err)
+		}
+	}
+	if results := FetchMany(context.Background(), nil, func() any { return new(pollInfo) }, 4); len(results) != 0 {
+		t.Errorf("FetchMany() of no targets = %v", results)
+	}
+
+	before := router.Requests()
+	for _, result := range FetchMany(context.Background(), targets, func() any { return new(badFetchInfo) }, 2) {
+		if !errors.Is(result.Err, ErrUnknownName) {
+			t.Errorf("FetchMany() with bad tag err = %v, want ErrUnknownName", result.Err)
+		}
+	}
+	if got := router.Requests() - before; got != 0 {
+		t.Errorf("FetchMany() with bad tag made %d requests, want 0", got)
+	}
+}
+
+func TestTargetConfigClient(t *testing.T) {
+	client := TargetConfig{Target: "192.0.2.1", Version: snmp.Version3, MsgFlags: snmp.AuthPriv,
+		SecurityParameters: &snmp.UsmSecurityParameters{UserName: "admin"}}.Client()
+	if client.Port != 161 || client.Timeout != 2*time.Second || client.MaxOids != snmp.MaxOids ||
+		client.SecurityModel != snmp.UserSecurityModel || client.Version != snmp.Version3 {
+		t.Errorf("Client() = %+v", client)
+	}
+}
+
+func TestFetchAndWalkStops(t *testing.T) {
+	agent := snmptest.NewAgent(nil)
+	client := agent.Client()
+	agent.Close()
+
+	ctx, cancel := context.WithTimeout(context.Background(), 100*time.Millisecond)
+	defer cancel()
+	err := fetchAndWalk(ctx, client, new(pollInfo))
+	if err == nil {
+		t.Fatalf("fetchAndWalk() from stopped agent succeeded")
+	}
+	// The walks aren't attempted once the Gets have failed
+	if strings.Contains(err.Error(), "walk ") {
+		t.Errorf("fetchAndWalk() err = %v, want no walk errors", err)
+	}
+}
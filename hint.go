@@ -0,0 +1,242 @@
+package gosnmpHelper
+
+import (
+	"fmt"
+	"github.com/gosnmp/gosnmp"
+	"math/big"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+)
+
+// Display hints already parsed, keyed by the hint
+var displayHints sync.Map
+
+// displayHint is a parsed DISPLAY-HINT, for either OctetStrings or INTEGERs
+type displayHint struct {
+	octets []octetSpec
+	// For INTEGER hints, the format (d, x, o or b) and the implied decimal places for d
+	intFormat byte
+	decimals  int
+}
+
+// octetSpec is one specification of an OctetString DISPLAY-HINT, such as "1x:" or "*1d."
+type octetSpec struct {
+	repeat     bool // the first octet gives the number of times to apply the specification
+	length     int  // octets used by each application
+	format     byte // d, x, o, a or t
+	separator  byte // output after each application, if not 0
+	terminator byte // output after the last repetition, if not 0
+}
+
+/*
+FormatDisplayHint formats the value of an OctetString or INTEGER PDU as directed by a DISPLAY-HINT from a
+textual convention, as described in RFC 2579.  For example:
+
+	FormatDisplayHint("1x:", pdu)         // 00:11:22:33:44:55 for a PhysAddress
+	FormatDisplayHint("255a", pdu)        // the text of a DisplayString
+	FormatDisplayHint("1d.1d.1d.1d", pdu) // 10.0.0.1
+	FormatDisplayHint("d-2", pdu)         // 12.34 for the Integer 1234
+
+OctetString hints apply each specification in turn, repeating the last one until the value is used up, and
+stop as soon as it is.  x is formatted in lowercase with two digits for each octet.  INTEGER hints are d,
+optionally followed by the number of implied decimal places, x, o or b.  The hint for a mib.Node is given by
+its DisplayHint() method.
+
+An error wrapping ErrInvalidHint is returned if the hint can't be parsed, or doesn't suit the type of the PDU,
+along with the value from GetAsString().
+*/
+func FormatDisplayHint(hint string, pdu gosnmp.SnmpPDU) (string, error) {
+	h, err := parseDisplayHint(hint)
+	if err != nil {
+		return GetAsString(pdu), err
+	}
+	s, ok := h.format(pdu)
+	if !ok {
+		return GetAsString(pdu), fmt.Errorf("%w %q for %s", ErrInvalidHint, hint, pdu.Type)
+	}
+	return s, nil
+}
+
+// parseDisplayHint returns the parsed form of a DISPLAY-HINT, which is cached
+func parseDisplayHint(hint string) (*displayHint, error) {
+	if h, ok := displayHints.Load(hint); ok {
+		return h.(*displayHint), nil
+	}
+	h := &displayHint{}
+	if err := h.parse(hint); err != nil {
+		return nil, fmt.Errorf("%w %q: %v", ErrInvalidHint, hint, err)
+	}
+	actual, _ := displayHints.LoadOrStore(hint, h)
+	return actual.(*displayHint), nil
+}
+
+// parse fills in the hint from its text
+func (h *displayHint) parse(hint string) error {
+	if len(hint) == 0 {
+		return fmt.Errorf("empty")
+	}
+	switch hint[0] {
+	case 'd':
+		h.intFormat = 'd'
+		if len(hint) > 1 {
+			places, ok := strings.CutPrefix(hint[1:], "-")
+			n, err := strconv.Atoi(places)
+			if !ok || err != nil || n < 0 || places[0] == '+' {
+				return fmt.Errorf("bad decimal places")
+			}
+			h.decimals = n
+		}
+		return nil
+	case 'x', 'o', 'b':
+		if len(hint) > 1 {
+			return fmt.Errorf("unexpected %q", hint[1:])
+		}
+		h.intFormat = hint[0]
+		return nil
+	}
+	for i := 0; i < len(hint); {
+		var spec octetSpec
+		if hint[i] == '*' {
+			spec.repeat = true
+			i++
+		}
+		start := i
+		for i < len(hint) && isDigit(hint[i]) {
+			i++
+		}
+		length, err := strconv.Atoi(hint[start:i])
+		if err != nil || length == 0 {
+			return fmt.Errorf("missing octet length at %d", start)
+		}
+		spec.length = length
+		if i == len(hint) || strings.IndexByte("dxoat", hint[i]) < 0 {
+			return fmt.Errorf("missing format at %d", i)
+		}
+		spec.format = hint[i]
+		i++
+		if i < len(hint) && !isDigit(hint[i]) && hint[i] != '*' {
+			spec.separator = hint[i]
+			i++
+			if spec.repeat && i < len(hint) && !isDigit(hint[i]) && hint[i] != '*' {
+				spec.terminator = hint[i]
+				i++
+			}
+		}
+		h.octets = append(h.octets, spec)
+	}
+	return nil
+}
+
+// isDigit reports whether c is a decimal digit
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+// format applies the hint to the PDU value, reporting false if the hint doesn't suit the value
+func (h *displayHint) format(pdu gosnmp.SnmpPDU) (string, bool) {
+	switch v := pdu.Value.(type) {
+	case []byte:
+		if h.octets == nil {
+			return "", false
+		}
+		return h.formatOctets(v), true
+	case string:
+		if h.octets == nil {
+			return "", false
+		}
+		return h.formatOctets([]byte(v)), true
+	case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+		if h.intFormat == 0 {
+			return "", false
+		}
+		return h.formatInteger(gosnmp.ToBigInt(v)), true
+	}
+	return "", false
+}
+
+// formatOctets applies an OctetString hint to data
+func (h *displayHint) formatOctets(data []byte) string {
+	var sb strings.Builder
+	for i := 0; len(data) > 0; i++ {
+		spec := h.octets[len(h.octets)-1]
+		if i < len(h.octets) {
+			spec = h.octets[i]
+		}
+		count := 1
+		if spec.repeat {
+			count = int(data[0])
+			data = data[1:]
+		}
+		for r := 0; r < count && len(data) > 0; r++ {
+			n := spec.length
+			if n > len(data) {
+				n = len(data)
+			}
+			spec.formatValue(&sb, data[:n])
+			data = data[n:]
+			switch {
+			case len(data) == 0:
+			case spec.repeat && r == count-1 && spec.terminator != 0:
+				sb.WriteByte(spec.terminator)
+			case spec.separator != 0:
+				sb.WriteByte(spec.separator)
+			}
+		}
+	}
+	return sb.String()
+}
+
+// formatValue writes the octets used by one application of the specification
+func (spec octetSpec) formatValue(sb *strings.Builder, octets []byte) {
+	switch spec.format {
+	case 'a', 't':
+		sb.Write(octets)
+	case 'x':
+		for _, b := range octets {
+			fmt.Fprintf(sb, "%02x", b)
+		}
+	case 'd':
+		sb.WriteString(new(big.Int).SetBytes(octets).String())
+	case 'o':
+		sb.WriteString(new(big.Int).SetBytes(octets).Text(8))
+	}
+}
+
+// formatInteger applies an INTEGER hint to v
+func (h *displayHint) formatInteger(v *big.Int) string {
+	switch h.intFormat {
+	case 'x':
+		return v.Text(16)
+	case 'o':
+		return v.Text(8)
+	case 'b':
+		return v.Text(2)
+	}
+	if h.decimals == 0 {
+		return v.String()
+	}
+	digits := new(big.Int).Abs(v).String()
+	if len(digits) <= h.decimals {
+		digits = strings.Repeat("0", h.decimals-len(digits)+1) + digits
+	}
+	point := len(digits) - h.decimals
+	s := digits[:point] + "." + digits[point:]
+	if v.Sign() < 0 {
+		s = "-" + s
+	}
+	return s
+}
+
+// hintPDU prepares a PDU for a string member with a hint tag by replacing its value with the formatted text.
+// PDUs the hint doesn't suit, and members of other types, are left as they are.
+func hintPDU(h *displayHint, pdu gosnmp.SnmpPDU, t reflect.Type) gosnmp.SnmpPDU {
+	if t.Kind() != reflect.String {
+		return pdu
+	}
+	if s, ok := h.format(pdu); ok {
+		pdu.Type, pdu.Value = gosnmp.OctetString, []byte(s)
+	}
+	return pdu
+}
@@ -0,0 +1,107 @@
+package gosnmpHelper
+
+import (
+	"errors"
+	snmp "github.com/gosnmp/gosnmp"
+	"reflect"
+	"testing"
+)
+
+func TestFormatDisplayHint(t *testing.T) {
+	octets := func(b ...byte) snmp.SnmpPDU { return snmp.SnmpPDU{Type: snmp.OctetString, Value: b} }
+	integer := func(v int) snmp.SnmpPDU { return snmp.SnmpPDU{Type: snmp.Integer, Value: v} }
+	tests := []struct {
+		hint string
+		pdu  snmp.SnmpPDU
+		want string
+		err  error
+	}{
+		{"1x:", octets(0x00, 0x11, 0x22, 0xaa, 0xbb, 0xcc), "00:11:22:aa:bb:cc", nil},
+		{"1x:", octets(), "", nil},
+		{"255a", octets([]byte("Linux router")...), "Linux router", nil},
+		{"255t", octets([]byte("Zürich")...), "Zürich", nil},
+		{"1d.1d.1d.1d", octets(10, 0, 0, 1), "10.0.0.1", nil},
+		{"1d.1d.1d.1d", octets(10, 0), "10.0", nil},
+		{"1d.", octets(192, 168, 1, 254, 7), "192.168.1.254.7", nil},
+		{"2d", octets(0x01, 0x00, 0xff), "256255", nil},
+		{"2d-1d", octets(0x07, 0xe6, 3), "2022-3", nil},
+		// DateAndTime
+		{"2d-1d-1d,1d:1d:1d.1d,1a1d:1d", octets(0x07, 0xe8, 5, 17, 13, 30, 15, 2, '+', 2, 0),
+			"2024-5-17,13:30:15.2,+2:0", nil},
+		{"2x", octets(0x12, 0x34, 0x05), "123405", nil},
+		{"1o", octets(8, 64), "10100", nil},
+		{"4x ", octets(0xde, 0xad, 0xbe, 0xef, 0x01), "deadbeef 01", nil},
+		{"*1d./1d", octets(3, 1, 2, 3, 24), "1.2.3/24", nil},
+		{"*1x:", octets(2, 0xab, 0xcd, 1, 0xef), "ab:cd:ef", nil},
+		{"*1d.,1a", octets(0, 'x'), "x", nil},
+		{"d", integer(1234), "1234", nil},
+		{"d-2", integer(1234), "12.34", nil},
+		{"d-2", integer(5), "0.05", nil},
+		{"d-2", integer(-5), "-0.05", nil},
+		{"d-1", snmp.SnmpPDU{Type: snmp.Gauge32, Value: uint(305)}, "30.5", nil},
+		{"x", integer(255), "ff", nil},
+		{"o", integer(8), "10", nil},
+		{"b", integer(5), "101", nil},
+		{"1x:", integer(5), "5", ErrInvalidHint},
+		{"d-2", octets([]byte("12")...), "12", ErrInvalidHint},
+		{"1x:", snmp.SnmpPDU{Type: snmp.Null}, "", ErrInvalidHint},
+		{"", octets([]byte("ab")...), "ab", ErrInvalidHint},
+		{"2d-1", octets([]byte("ab")...), "ab", ErrInvalidHint},
+		{"xx", integer(1), "1", ErrInvalidHint},
+		{"d-", integer(1), "1", ErrInvalidHint},
+		{"0a", octets([]byte("ab")...), "ab", ErrInvalidHint},
+		{"1q", octets([]byte("ab")...), "ab", ErrInvalidHint},
+	}
+	for _, tt := range tests {
+		got, err := FormatDisplayHint(tt.hint, tt.pdu)
+		if got != tt.want || !errors.Is(err, tt.err) {
+			t.Errorf("FormatDisplayHint(%q, %v) = %q, %v, want %q, %v", tt.hint, tt.pdu.Value, got, err, tt.want, tt.err)
+		}
+	}
+}
+
+type hintRow struct {
+	Descr    string `oidcol:"2"`
+	PhysAddr string `oidcol:"6" hint:"1x:"`
+}
+
+type hintInfo struct {
+	Location string             `oid:".1.3.6.1.2.1.1.6.0" hint:"255a"`
+	Temp     string             `oid:".1.3.6.1.4.1.9999.1.0" hint:"d-1"`
+	Raw      []byte             `oid:".1.3.6.1.4.1.9999.2.0" hint:"1x:"`
+	Addrs    map[string]string  `oidx:"IP-MIB::ipNetToMediaPhysAddress.(.+)" hint:"1x:"`
+	Rows     map[string]hintRow `oidtable:".1.3.6.1.2.1.2.2.1"`
+}
+
+type badHintInfo struct {
+	Addr string `oid:".1.3.6.1.2.1.2.2.1.6.1" hint:"1q"`
+}
+
+func TestHintTag(t *testing.T) {
+	pdus := []snmp.SnmpPDU{
+		{Name: ".1.3.6.1.2.1.1.6.0", Type: snmp.OctetString, Value: []byte("Rack 12")},
+		{Name: ".1.3.6.1.4.1.9999.1.0", Type: snmp.Integer, Value: 215},
+		{Name: ".1.3.6.1.4.1.9999.2.0", Type: snmp.OctetString, Value: []byte{0x01, 0x02}},
+		{Name: ".1.3.6.1.2.1.2.2.1.2.2", Type: snmp.OctetString, Value: []byte("eth0")},
+		{Name: ".1.3.6.1.2.1.2.2.1.6.2", Type: snmp.OctetString, Value: []byte{0x00, 0x11, 0x22, 0x33, 0x44, 0x55}},
+		{Name: ".1.3.6.1.2.1.4.22.1.2.2.10.0.0.1", Type: snmp.OctetString, Value: []byte{0x00, 0x66, 0x77, 0x88, 0x99, 0xaa}},
+	}
+	var info hintInfo
+	if err := MarshalPDUsToStructE(pdus, &info); err != nil {
+		t.Fatalf("MarshalPDUsToStructE() err = %v", err)
+	}
+	want := hintInfo{
+		Location: "Rack 12",
+		Temp:     "21.5",
+		Raw:      []byte{0x01, 0x02},
+		Addrs:    map[string]string{"2.10.0.0.1": "00:66:77:88:99:aa"},
+		Rows:     map[string]hintRow{"2": {Descr: "eth0", PhysAddr: "00:11:22:33:44:55"}},
+	}
+	if !reflect.DeepEqual(info, want) {
+		t.Errorf("MarshalPDUsToStructE() = %+v, want %+v", info, want)
+	}
+
+	if _, err := Compile[badHintInfo](); !errors.Is(err, ErrInvalidHint) {
+		t.Errorf("Compile() err = %v, want ErrInvalidHint", err)
+	}
+}
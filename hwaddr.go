@@ -0,0 +1,45 @@
+package gosnmpHelper
+
+import (
+	"fmt"
+	"github.com/gosnmp/gosnmp"
+	"net"
+	"reflect"
+)
+
+var (
+	typeHardwareAddr = reflect.TypeOf(net.HardwareAddr(nil))
+	typeMac6         = reflect.TypeOf([6]byte{})
+)
+
+func getHardwareAddrValue(pdu gosnmp.SnmpPDU) (reflect.Value, error) {
+	mac, err := GetAsMAC(pdu)
+	if err != nil {
+		return reflect.ValueOf(mac), fmt.Errorf("%w: %w", ErrConversion, err)
+	}
+	return reflect.ValueOf(mac), nil
+}
+
+func getMac6Value(pdu gosnmp.SnmpPDU) (reflect.Value, error) {
+	var mac6 [6]byte
+	mac, err := GetAsMAC(pdu)
+	if err != nil {
+		return reflect.ValueOf(mac6), fmt.Errorf("%w: %w", ErrConversion, err)
+	}
+	copy(mac6[:], mac)
+	return reflect.ValueOf(mac6), nil
+}
+
+// macPDUValue converts a net.HardwareAddr or [6]byte member into the raw bytes of an OctetString
+func macPDUValue(asnType gosnmp.Asn1BER, v reflect.Value) (gosnmp.Asn1BER, interface{}, bool) {
+	if asnType != 0 && asnType != gosnmp.OctetString {
+		return asnType, nil, false
+	}
+	switch mac := v.Interface().(type) {
+	case net.HardwareAddr:
+		return gosnmp.OctetString, []byte(mac), len(mac) > 0
+	case [6]byte:
+		return gosnmp.OctetString, mac[:], true
+	}
+	return asnType, nil, false
+}
@@ -0,0 +1,67 @@
+// Package oids has the OID handling behind gosnmpHelper.OID, shared with the subpackages, which cannot use
+// gosnmpHelper.OID as the gosnmpHelper tests use them.
+package oids
+
+import (
+	"fmt"
+	"strconv"
+	"strings"
+)
+
+// Parse parses a dotted OID with or without the leading dot, ignoring surrounding white space
+func Parse(s string) ([]uint32, error) {
+	trimmed := strings.TrimPrefix(strings.TrimSpace(s), ".")
+	if len(trimmed) == 0 {
+		return nil, fmt.Errorf("invalid OID %q", s)
+	}
+	parts := strings.Split(trimmed, ".")
+	oid := make([]uint32, len(parts))
+	for i, part := range parts {
+		n, err := strconv.ParseUint(part, 10, 32)
+		if err != nil {
+			return nil, fmt.Errorf("invalid OID %q", s)
+		}
+		oid[i] = uint32(n)
+	}
+	return oid, nil
+}
+
+// Format returns the OID in dotted form with a leading dot, as gosnmp returns PDU names.  The empty OID is
+// formatted as "."
+func Format(oid []uint32) string {
+	if len(oid) == 0 {
+		return "."
+	}
+	b := make([]byte, 0, len(oid)*4)
+	for _, n := range oid {
+		b = append(b, '.')
+		b = strconv.AppendUint(b, uint64(n), 10)
+	}
+	return string(b)
+}
+
+// Compare compares OIDs in SNMP order, returning -1, 0 or +1.  An OID sorts before any OID it is a prefix of.
+func Compare(a, b []uint32) int {
+	for i := 0; i < len(a) && i < len(b); i++ {
+		if a[i] < b[i] {
+			return -1
+		} else if a[i] > b[i] {
+			return 1
+		}
+	}
+	switch {
+	case len(a) < len(b):
+		return -1
+	case len(a) > len(b):
+		return 1
+	}
+	return 0
+}
+
+// HasPrefix reports whether prefix is the start of oid
+func HasPrefix(oid, prefix []uint32) bool {
+	if len(prefix) > len(oid) {
+		return false
+	}
+	return Compare(oid[:len(prefix)], prefix) == 0
+}
@@ -0,0 +1,93 @@
+package gosnmpHelper
+
+import (
+	"fmt"
+	"github.com/gosnmp/gosnmp"
+	"net"
+	"net/netip"
+	"reflect"
+	"strings"
+)
+
+var (
+	typeIP     = reflect.TypeOf(net.IP(nil))
+	typeAddr   = reflect.TypeOf(netip.Addr{})
+	typePrefix = reflect.TypeOf(netip.Prefix{})
+)
+
+// ipError returns the error for a PDU which could not be decoded as an address
+func ipError(pdu gosnmp.SnmpPDU) error {
+	if pdu.Value == nil {
+		return fmt.Errorf("%w: %w", ErrConversion, ErrNilValue)
+	}
+	return fmt.Errorf("%w: %w", ErrConversion, ErrParse)
+}
+
+func getIPValue(pdu gosnmp.SnmpPDU) (reflect.Value, error) {
+	ip := GetAsIP(pdu)
+	if ip == nil {
+		return reflect.ValueOf(ip), ipError(pdu)
+	}
+	return reflect.ValueOf(ip), nil
+}
+
+func getAddrValue(pdu gosnmp.SnmpPDU) (reflect.Value, error) {
+	addr, ok := netip.AddrFromSlice(GetAsIP(pdu))
+	if !ok {
+		return reflect.ValueOf(addr), ipError(pdu)
+	}
+	return reflect.ValueOf(addr), nil
+}
+
+// getPrefixValue decodes text such as "10.1.0.0/16", otherwise the address is taken as a single host prefix
+func getPrefixValue(pdu gosnmp.SnmpPDU) (reflect.Value, error) {
+	if s := GetAsString(pdu); strings.Contains(s, "/") {
+		if prefix, err := netip.ParsePrefix(s); err == nil {
+			return reflect.ValueOf(prefix), nil
+		}
+		return reflect.ValueOf(netip.Prefix{}), ipError(pdu)
+	}
+	addr, ok := netip.AddrFromSlice(GetAsIP(pdu))
+	if !ok {
+		return reflect.ValueOf(netip.Prefix{}), ipError(pdu)
+	}
+	return reflect.ValueOf(netip.PrefixFrom(addr, addr.BitLen())), nil
+}
+
+// ipPDUValue converts an address member into a value for the ASN.1 type.  IPAddress is only possible for
+// IPv4 addresses, an OctetString holds the address in InetAddress form (4 or 16 bytes).  If asnType is 0,
+// IPAddress is chosen for IPv4 addresses and OctetString for IPv6.
+func ipPDUValue(asnType gosnmp.Asn1BER, v reflect.Value) (gosnmp.Asn1BER, interface{}, bool) {
+	var addr netip.Addr
+	switch a := v.Interface().(type) {
+	case net.IP:
+		var ok bool
+		if addr, ok = netip.AddrFromSlice(a); !ok {
+			return asnType, nil, false
+		}
+	case netip.Addr:
+		addr = a
+	case netip.Prefix:
+		if asnType == 0 || asnType == gosnmp.OctetString {
+			return gosnmp.OctetString, a.String(), a.IsValid()
+		}
+		return asnType, nil, false
+	}
+	addr = addr.Unmap()
+	if !addr.IsValid() {
+		return asnType, nil, false
+	}
+	if asnType == 0 {
+		asnType = gosnmp.OctetString
+		if addr.Is4() {
+			asnType = gosnmp.IPAddress
+		}
+	}
+	switch asnType {
+	case gosnmp.IPAddress:
+		return asnType, addr.String(), addr.Is4()
+	case gosnmp.OctetString:
+		return asnType, addr.AsSlice(), true
+	}
+	return asnType, nil, false
+}
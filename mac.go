@@ -1,7 +1,9 @@
 package gosnmpHelper
 
 import (
+	"encoding/hex"
 	"fmt"
+	"net"
 	"regexp"
 	"strings"
 )
@@ -35,3 +37,16 @@ func NormalizeMac(input string) (string, error) {
 	}
 	return "", fmt.Errorf("invalid format for MAC address '%-1.20s'", input)
 }
+
+// parseMac converts a MAC address in any of the formats accepted by NormalizeMac into a net.HardwareAddr
+func parseMac(input string) (net.HardwareAddr, error) {
+	normalized, err := NormalizeMac(input)
+	if err != nil {
+		return nil, err
+	}
+	mac, err := hex.DecodeString(normalized)
+	if err != nil {
+		return nil, err
+	}
+	return net.HardwareAddr(mac), nil
+}
@@ -0,0 +1,157 @@
+package gosnmpHelper
+
+import (
+	"errors"
+	"fmt"
+	"github.com/jjcinaz/gosnmpHelper/mib"
+	"regexp"
+	"strings"
+	"sync"
+)
+
+// The symbol table used to resolve names such as "IF-MIB::ifDescr", keyed by module then object name
+var mibNames = struct {
+	sync.RWMutex
+	modules map[string]map[string]OID
+}{modules: map[string]map[string]OID{}}
+
+// A symbolic name at the start of an oidx pattern, such as "IF-MIB::ifDescr" in `IF-MIB::ifDescr\.(\d+)`
+var patternNameRx = regexp.MustCompile(`^([A-Za-z][\w-]*::[A-Za-z][\w-]*)`)
+
+func init() {
+	for module, names := range builtinNames {
+		if err := RegisterNames(module, names); err != nil {
+			panic(err)
+		}
+	}
+}
+
+/*
+RegisterNames adds names to the symbol table used for the symbolic OIDs in struct tags, such as
+`oid:"SNMPv2-MIB::sysDescr.0"`.  The names map object names to their numeric OIDs, for example:
+
+	err := RegisterNames("UCD-SNMP-MIB", map[string]string{
+		"laTable": ".1.3.6.1.4.1.2021.10",
+		"laLoad":  ".1.3.6.1.4.1.2021.10.1.3",
+	})
+
+Names already registered for the module are replaced.  The table comes loaded with the commonly used objects
+of SNMPv2-MIB, IF-MIB, IP-MIB, BRIDGE-MIB, ENTITY-MIB and HOST-RESOURCES-MIB.  Struct types are analyzed
+once and cached, so names must be registered before the first use of any struct whose tags refer to them;
+an init() function is a good place.  An error wrapping ErrInvalidOID is returned, and nothing is registered,
+if any of the OIDs are invalid.
+*/
+func RegisterNames(module string, names map[string]string) error {
+	parsed := make(map[string]OID, len(names))
+	for name, s := range names {
+		oid, err := ParseOID(s)
+		if err != nil {
+			return fmt.Errorf("%s::%s: %w", module, name, err)
+		}
+		parsed[name] = oid
+	}
+	mibNames.Lock()
+	defer mibNames.Unlock()
+	m := mibNames.modules[module]
+	if m == nil {
+		m = make(map[string]OID, len(parsed))
+		mibNames.modules[module] = m
+	}
+	for name, oid := range parsed {
+		m[name] = oid
+	}
+	return nil
+}
+
+/*
+RegisterTree adds the names of every module loaded into a MIB tree to the symbol table, so that any MIB
+available as a file can be used in struct tags:
+
+	tree := mib.NewTree("/usr/share/snmp/mibs")
+	if err := tree.Load("CISCO-PROCESS-MIB"); err != nil {
+		...
+	}
+	err := RegisterTree(tree)
+
+The names are copied, so modules loaded into the tree afterwards need another call.
+*/
+func RegisterTree(tree *mib.Tree) error {
+	var errs []error
+	for _, m := range tree.Modules() {
+		names := make(map[string]string, len(m.Nodes))
+		for _, n := range m.Nodes {
+			if n.OID != nil {
+				names[n.Name] = OID(n.OID).String()
+			}
+		}
+		if err := RegisterNames(m.Name, names); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
+
+/*
+ResolveName returns the OID for a name in the form "MODULE::object", optionally followed by sub-identifiers
+such as the instance in "IF-MIB::ifDescr.6".  Numeric OIDs are also accepted and returned as they are.
+An error wrapping ErrUnknownName is returned if the module or object have not been registered, and one
+wrapping ErrInvalidOID if the sub-identifiers are not numeric.
+*/
+func ResolveName(name string) (OID, error) {
+	module, object, ok := strings.Cut(strings.TrimSpace(name), "::")
+	if !ok {
+		return ParseOID(name)
+	}
+	object, subs, _ := strings.Cut(object, ".")
+	mibNames.RLock()
+	base, found := mibNames.modules[module][object]
+	mibNames.RUnlock()
+	if !found {
+		return nil, fmt.Errorf("%w %q", ErrUnknownName, name)
+	}
+	if len(subs) == 0 {
+		return append(OID{}, base...), nil
+	}
+	suffix, err := ParseOID(subs)
+	if err != nil {
+		return nil, fmt.Errorf("%w %q", ErrInvalidOID, name)
+	}
+	return base.Append(suffix...), nil
+}
+
+// isSymbolic reports whether an oid, oidtable or walk tag uses a name rather than a numeric OID
+func isSymbolic(tag string) bool {
+	return strings.Contains(tag, "::")
+}
+
+// resolveTag returns the numeric form of a symbolic oid, oidtable or walk tag.  Other tags are returned as
+// they are.
+func resolveTag(tag string) (string, error) {
+	if !isSymbolic(tag) {
+		return tag, nil
+	}
+	oid, err := ResolveName(tag)
+	if err != nil {
+		return tag, err
+	}
+	return oid.String(), nil
+}
+
+// resolvePattern replaces a name at the start of an oidx pattern with its OID as escaped literal text.
+// The pattern is anchored at the start as the name gives the full OID.  A dot straight after the name
+// is taken to be the sub-identifier separator rather than the wildcard.
+func resolvePattern(pattern string) (string, error) {
+	name := patternNameRx.FindString(strings.TrimPrefix(pattern, "^"))
+	if len(name) == 0 {
+		return pattern, nil
+	}
+	oid, err := ResolveName(name)
+	if err != nil {
+		return pattern, err
+	}
+	rest := strings.TrimPrefix(pattern, "^")[len(name):]
+	if strings.HasPrefix(rest, ".") {
+		rest = `\.` + rest[1:]
+	}
+	return "^" + regexp.QuoteMeta(oid.String()) + rest, nil
+}
@@ -0,0 +1,128 @@
+package mib
+
+import (
+	"fmt"
+	"strings"
+)
+
+// A token of SMI source
+type token struct {
+	text   string
+	line   int
+	quoted bool // a quoted string, with text holding the contents
+}
+
+// lexer splits SMI source into tokens, dropping comments
+type lexer struct {
+	src  string
+	pos  int
+	line int
+}
+
+// tokenize returns all the tokens in src
+func tokenize(src string) ([]token, error) {
+	l := lexer{src: src, line: 1}
+	var tokens []token
+	for {
+		tok, ok, err := l.next()
+		if err != nil {
+			return tokens, err
+		}
+		if !ok {
+			return tokens, nil
+		}
+		tokens = append(tokens, tok)
+	}
+}
+
+// next returns the next token, or false at the end of the source
+func (l *lexer) next() (token, bool, error) {
+	l.skipSpace()
+	if l.pos >= len(l.src) {
+		return token{}, false, nil
+	}
+	start, line := l.pos, l.line
+	c := l.src[l.pos]
+	switch {
+	case c == '"':
+		// Strings may span lines, and a doubled quote stands for a quote
+		var sb strings.Builder
+		for l.pos++; l.pos < len(l.src); l.pos++ {
+			c = l.src[l.pos]
+			if c == '"' {
+				if l.pos+1 < len(l.src) && l.src[l.pos+1] == '"' {
+					sb.WriteByte('"')
+					l.pos++
+					continue
+				}
+				l.pos++
+				return token{text: sb.String(), line: line, quoted: true}, true, nil
+			}
+			if c == '\n' {
+				l.line++
+			}
+			sb.WriteByte(c)
+		}
+		return token{}, false, fmt.Errorf("line %d: %w: unterminated string", line, ErrSyntax)
+	case c == '\'':
+		// Binary or hex string such as '0F'H
+		end := strings.IndexByte(l.src[l.pos+1:], '\'')
+		if end < 0 {
+			return token{}, false, fmt.Errorf("line %d: %w: unterminated quoted value", line, ErrSyntax)
+		}
+		l.pos += end + 2
+		if l.pos < len(l.src) && strings.ContainsRune("HhBb", rune(l.src[l.pos])) {
+			l.pos++
+		}
+	case strings.HasPrefix(l.src[l.pos:], "::="):
+		l.pos += 3
+	case strings.HasPrefix(l.src[l.pos:], ".."):
+		l.pos += 2
+	case isWordByte(c) || (c == '-' && l.pos+1 < len(l.src) && isDigit(l.src[l.pos+1])):
+		for l.pos++; l.pos < len(l.src); l.pos++ {
+			c = l.src[l.pos]
+			if c == '-' && strings.HasPrefix(l.src[l.pos:], "--") {
+				break
+			}
+			if !isWordByte(c) && c != '-' {
+				break
+			}
+		}
+	default:
+		l.pos++
+	}
+	return token{text: l.src[start:l.pos], line: line}, true, nil
+}
+
+// skipSpace skips white space and comments.  Comments run from -- to the end of the line or the next --.
+func (l *lexer) skipSpace() {
+	for l.pos < len(l.src) {
+		c := l.src[l.pos]
+		switch {
+		case c == '\n':
+			l.line++
+			l.pos++
+		case c == ' ' || c == '\t' || c == '\r' || c == '\f':
+			l.pos++
+		case strings.HasPrefix(l.src[l.pos:], "--"):
+			l.pos += 2
+			for l.pos < len(l.src) && l.src[l.pos] != '\n' {
+				if strings.HasPrefix(l.src[l.pos:], "--") {
+					l.pos += 2
+					break
+				}
+				l.pos++
+			}
+		default:
+			return
+		}
+	}
+}
+
+func isDigit(c byte) bool {
+	return c >= '0' && c <= '9'
+}
+
+func isWordByte(c byte) bool {
+	return isDigit(c) || (c >= 'a' && c <= 'z') || (c >= 'A' && c <= 'Z') || c == '_'
+}
@@ -0,0 +1,205 @@
+/*
+Package mib loads SMIv1 and SMIv2 MIB modules from local directories into a Tree which resolves object names
+to OIDs and back, and gives the SYNTAX, enumerations and DISPLAY-HINT of each object.  It is written in pure Go
+so no net-snmp installation is needed, only the MIB files:
+
+	tree := mib.NewTree("/usr/share/snmp/mibs", "./mibs")
+	if err := tree.Load("IF-MIB"); err != nil {
+		...
+	}
+	oid, err := tree.Resolve("IF-MIB::ifOperStatus.6")
+	node, index := tree.Lookup(oid)     // ifOperStatus, [6]
+	fmt.Println(node.Enums())           // [{up 1} {down 2} ...]
+
+Modules are found by the name they declare, whatever their file names.  Imported modules are loaded as they
+are needed.  SNMPv2-SMI, SNMPv2-TC, SNMPv2-CONF, RFC1155-SMI, RFC-1212 and RFC-1215 are built in, so only the
+MIBs of interest need to be supplied.
+
+Only the parts of the SMI needed to resolve names and decode values are kept.  DEFVAL clauses, size and range
+constraints, and the contents of conformance statements such as MODULE-COMPLIANCE are skipped.
+*/
+package mib
+
+import (
+	"errors"
+	"strconv"
+	"strings"
+)
+
+// ErrSyntax is returned when a MIB file cannot be parsed
+var ErrSyntax = errors.New("invalid MIB syntax")
+
+// ErrModuleNotFound is returned when no file in the search directories declares a module
+var ErrModuleNotFound = errors.New("MIB module not found")
+
+// ErrUnknownSymbol is returned when a name used by a module is neither defined nor imported
+var ErrUnknownSymbol = errors.New("unknown MIB symbol")
+
+// Kind is the SMI macro which defined a node
+type Kind int
+
+const (
+	KindObjectIdentifier Kind = iota // plain OBJECT IDENTIFIER value assignment
+	KindModuleIdentity
+	KindObjectIdentity
+	KindObjectType
+	KindNotificationType
+	KindTrapType // SMIv1 TRAP-TYPE, placed at enterprise.0.n
+	KindGroup    // OBJECT-GROUP and NOTIFICATION-GROUP
+	KindCompliance
+	KindCapabilities
+)
+
+var kindNames = [...]string{"OBJECT IDENTIFIER", "MODULE-IDENTITY", "OBJECT-IDENTITY", "OBJECT-TYPE",
+	"NOTIFICATION-TYPE", "TRAP-TYPE", "OBJECT-GROUP", "MODULE-COMPLIANCE", "AGENT-CAPABILITIES"}
+
+func (k Kind) String() string {
+	if k >= 0 && int(k) < len(kindNames) {
+		return kindNames[k]
+	}
+	return "Kind(" + strconv.Itoa(int(k)) + ")"
+}
+
+// Module is a MIB module, such as IF-MIB
+type Module struct {
+	Name    string
+	Imports map[string]string // imported symbol to the module it comes from
+	Nodes   []*Node           // in the order defined
+	Types   []*TextualConvention
+
+	nodes    map[string]*Node
+	types    map[string]*TextualConvention
+	resolved bool
+}
+
+// Node is a named OID defined by a module, with the details given by the macro defining it
+type Node struct {
+	Name        string
+	Module      string
+	Kind        Kind
+	OID         []uint32 // set once the module is loaded into a Tree
+	Syntax      *Syntax  // for OBJECT-TYPE
+	TC          *TextualConvention
+	Access      string
+	Status      string
+	Units       string
+	Description string
+	Index       []string // for table entries, the INDEX objects.  IMPLIED is dropped.
+	Augments    string   // for table entries augmenting another, the entry augmented
+	Objects     []string // OBJECTS of a NOTIFICATION-TYPE or group, or VARIABLES of a TRAP-TYPE
+
+	parent string  // the first component of the value, or empty if it's numeric
+	subs   []subID // the remaining components
+	line   int
+}
+
+// subID is a component of an OID value, such as 6 or dod(6)
+type subID struct {
+	name string
+	num  uint32
+}
+
+// Syntax is the SYNTAX of an object or textual convention
+type Syntax struct {
+	Type  string // such as "INTEGER", "OCTET STRING", "DisplayString" or "SEQUENCE OF IfEntry"
+	Enums []Enum // named numbers of an INTEGER, or bits of BITS
+}
+
+// Enum is a named number of an INTEGER or BITS syntax, such as up(1)
+type Enum struct {
+	Name  string
+	Value int64
+}
+
+// TextualConvention is a type defined by a TEXTUAL-CONVENTION or a plain type assignment
+type TextualConvention struct {
+	Name        string
+	Module      string
+	DisplayHint string
+	Status      string
+	Description string
+	Syntax      *Syntax
+	Parent      *TextualConvention // set once loaded when Syntax.Type is itself a defined type
+
+	line int
+}
+
+// The SMI base types, which end a chain of textual conventions
+var baseTypes = map[string]bool{
+	"INTEGER": true, "OCTET STRING": true, "OBJECT IDENTIFIER": true, "BITS": true,
+	"Integer32": true, "Unsigned32": true, "Counter32": true, "Counter64": true, "Gauge32": true,
+	"TimeTicks": true, "IpAddress": true, "Opaque": true, "Counter": true, "Gauge": true,
+	"NetworkAddress": true, "SEQUENCE": true,
+}
+
+// isBaseType reports whether the syntax type is an SMI base type rather than a defined type
+func isBaseType(typ string) bool {
+	return baseTypes[typ] || strings.HasPrefix(typ, "SEQUENCE OF ")
+}
+
+// BaseType returns the SMI base type of the node's syntax, following any textual conventions.  For example
+// ifDescr, with SYNTAX DisplayString, gives "OCTET STRING".  It is empty for nodes without a SYNTAX.
+func (n *Node) BaseType() string {
+	if n.Syntax == nil {
+		return ""
+	}
+	if n.TC != nil {
+		return n.TC.BaseType()
+	}
+	return n.Syntax.Type
+}
+
+// DisplayHint returns the DISPLAY-HINT of the node's textual convention, or the nearest one it's derived from
+func (n *Node) DisplayHint() string {
+	if n.TC == nil {
+		return ""
+	}
+	return n.TC.displayHint()
+}
+
+// Enums returns the named numbers for the node, from its own SYNTAX or else its textual convention
+func (n *Node) Enums() []Enum {
+	if n.Syntax == nil {
+		return nil
+	}
+	if len(n.Syntax.Enums) > 0 || n.TC == nil {
+		return n.Syntax.Enums
+	}
+	return n.TC.Enums()
+}
+
+// IsTable reports whether the node is a table, a SEQUENCE OF entries
+func (n *Node) IsTable() bool {
+	return n.Syntax != nil && strings.HasPrefix(n.Syntax.Type, "SEQUENCE OF ")
+}
+
+// IsEntry reports whether the node is a table entry, with an INDEX or AUGMENTS clause
+func (n *Node) IsEntry() bool {
+	return n.Kind == KindObjectType && (len(n.Index) > 0 || len(n.Augments) > 0)
+}
+
+// BaseType returns the SMI base type the textual convention is derived from
+func (tc *TextualConvention) BaseType() string {
+	for ; tc.Parent != nil; tc = tc.Parent {
+	}
+	return tc.Syntax.Type
+}
+
+// Enums returns the named numbers of the textual convention, or of the nearest one it's derived from
+func (tc *TextualConvention) Enums() []Enum {
+	for ; tc != nil; tc = tc.Parent {
+		if len(tc.Syntax.Enums) > 0 {
+			return tc.Syntax.Enums
+		}
+	}
+	return nil
+}
+
+func (tc *TextualConvention) displayHint() string {
+	for ; tc != nil; tc = tc.Parent {
+		if len(tc.DisplayHint) > 0 {
+			return tc.DisplayHint
+		}
+	}
+	return ""
+}
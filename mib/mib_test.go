@@ -0,0 +1,187 @@
+package mib
+
+import (
+	"errors"
+	"github.com/jjcinaz/gosnmpHelper/internal/oids"
+	"io/fs"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestTree(t *testing.T) {
+	tree := NewTree("testdata")
+	if err := tree.Load("IF-MIB"); err != nil {
+		t.Fatalf("Load() err = %v", err)
+	}
+	for _, name := range []string{"IF-MIB", "IANAifType-MIB", "SNMPv2-MIB", "SNMPv2-TC"} {
+		if tree.Module(name) == nil {
+			t.Errorf("module %s was not loaded", name)
+		}
+	}
+	tests := []struct {
+		name    string
+		oid     string
+		format  string
+		kind    Kind
+		base    string
+		hint    string
+		enums   int
+		wantErr bool
+	}{
+		{name: "IF-MIB::ifDescr.6", oid: ".1.3.6.1.2.1.2.2.1.2.6", kind: KindObjectType, base: "OCTET STRING", hint: "255a"},
+		{name: "ifOperStatus", oid: ".1.3.6.1.2.1.2.2.1.8", format: "IF-MIB::ifOperStatus", kind: KindObjectType, base: "INTEGER", enums: 7},
+		{name: "IF-MIB::ifAdminStatus", oid: ".1.3.6.1.2.1.2.2.1.7", kind: KindObjectType, base: "INTEGER", enums: 3},
+		{name: "IF-MIB::ifType", oid: ".1.3.6.1.2.1.2.2.1.3", kind: KindObjectType, base: "INTEGER", enums: 71},
+		{name: "IF-MIB::ifIndex", oid: ".1.3.6.1.2.1.2.2.1.1", kind: KindObjectType, base: "Integer32", hint: "d"},
+		{name: "IF-MIB::ifPhysAddress", oid: ".1.3.6.1.2.1.2.2.1.6", kind: KindObjectType, base: "OCTET STRING", hint: "1x:"},
+		{name: "IF-MIB::ifPromiscuousMode.2", oid: ".1.3.6.1.2.1.31.1.1.1.16.2", kind: KindObjectType, base: "INTEGER", enums: 2},
+		{name: "IF-MIB::ifHCInOctets", oid: ".1.3.6.1.2.1.31.1.1.1.6", kind: KindObjectType, base: "Counter64"},
+		{name: "IF-MIB::ifTable", oid: ".1.3.6.1.2.1.2.2", kind: KindObjectType, base: "SEQUENCE OF IfEntry"},
+		{name: "IF-MIB::ifMIB", oid: ".1.3.6.1.2.1.31", kind: KindModuleIdentity},
+		{name: "IF-MIB::linkDown", oid: ".1.3.6.1.6.3.1.1.5.3", kind: KindNotificationType},
+		{name: "IF-MIB::ifCompliance3", oid: ".1.3.6.1.2.1.31.2.2.3", kind: KindCompliance},
+		{name: "IF-MIB::ifGeneralInformationGroup", oid: ".1.3.6.1.2.1.31.2.1.10", kind: KindGroup},
+		{name: "SNMPv2-MIB::sysUpTime.0", oid: ".1.3.6.1.2.1.1.3.0", kind: KindObjectType, base: "TimeTicks"},
+		{name: "SNMPv2-SMI::enterprises", oid: ".1.3.6.1.4.1", kind: KindObjectIdentifier},
+		{name: "1.3.6.1.2.1.2.2.1.2.6", oid: ".1.3.6.1.2.1.2.2.1.2.6", format: "IF-MIB::ifDescr.6", kind: KindObjectType, base: "OCTET STRING", hint: "255a"},
+		{name: "IF-MIB::ifAdminStatus.x", wantErr: true},
+		{name: "IF-MIB::sysDescr", wantErr: true},
+		{name: "noSuchObject.0", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			oid, err := tree.Resolve(tt.name)
+			if tt.wantErr {
+				if !errors.Is(err, ErrUnknownSymbol) {
+					t.Errorf("Resolve() err = %v, want ErrUnknownSymbol", err)
+				}
+				return
+			}
+			if err != nil || oids.Format(oid) != tt.oid {
+				t.Fatalf("Resolve() = %s, %v, want %s", oids.Format(oid), err, tt.oid)
+			}
+			format := tt.format
+			if len(format) == 0 {
+				format = tt.name
+			}
+			if got := tree.Format(oid); got != format {
+				t.Errorf("Format() = %s, want %s", got, format)
+			}
+			n, _ := tree.Lookup(oid)
+			if n.Kind != tt.kind || n.BaseType() != tt.base || n.DisplayHint() != tt.hint || len(n.Enums()) != tt.enums {
+				t.Errorf("Lookup() = %v %q %q %v", n.Kind, n.BaseType(), n.DisplayHint(), n.Enums())
+			}
+		})
+	}
+
+	entry := tree.Node("IF-MIB::ifEntry")
+	if !entry.IsEntry() || !reflect.DeepEqual(entry.Index, []string{"ifIndex"}) || entry.IsTable() {
+		t.Errorf("ifEntry = %+v", entry)
+	}
+	if xEntry := tree.Node("ifXEntry"); !xEntry.IsEntry() || xEntry.Augments != "ifEntry" {
+		t.Errorf("ifXEntry = %+v", xEntry)
+	}
+	status := tree.Node("IF-MIB::ifOperStatus")
+	if status.Access != "read-only" || status.Status != "current" || status.Enums()[6] != (Enum{Name: "lowerLayerDown", Value: 7}) {
+		t.Errorf("ifOperStatus = %+v", status)
+	}
+	if units := tree.Node("IF-MIB::ifHighSpeed").Units; units != "Mb/s" {
+		t.Errorf("ifHighSpeed units = %q", units)
+	}
+	if desc := tree.Node("IF-MIB::ifMIB").Description; !strings.HasPrefix(desc, "The MIB module to describe") {
+		t.Errorf("ifMIB description = %q", desc)
+	}
+	if objects := tree.Node("IF-MIB::linkDown").Objects; !reflect.DeepEqual(objects, []string{"ifIndex", "ifAdminStatus", "ifOperStatus"}) {
+		t.Errorf("linkDown objects = %v", objects)
+	}
+	if n, rest := tree.Lookup([]uint32{1, 3, 6, 1, 4, 1, 9, 1}); n.Name != "enterprises" || !reflect.DeepEqual(rest, []uint32{9, 1}) {
+		t.Errorf("Lookup() = %v, %v", n, rest)
+	}
+	if n, _ := tree.Lookup([]uint32{3, 1}); n != nil || tree.Format([]uint32{3, 1}) != ".3.1" {
+		t.Errorf("Lookup() of unknown OID = %v", n)
+	}
+}
+
+func TestLoadFile(t *testing.T) {
+	tree := NewTree("testdata")
+	if err := tree.LoadFile("testdata/ACME-MIB.txt"); err != nil {
+		t.Fatalf("LoadFile() err = %v", err)
+	}
+	tests := []struct {
+		name  string
+		oid   string
+		base  string
+		enums []Enum
+	}{
+		{name: "ACME-MIB::acmeName", oid: ".1.3.6.1.4.1.99999.1.1", base: "OCTET STRING"},
+		{name: "ACME-MIB::acmeState", oid: ".1.3.6.1.4.1.99999.1.2", base: "INTEGER",
+			enums: []Enum{{"ok", 1}, {"degraded", 2}, {"failed", 3}}},
+		{name: "ACME-MIB::acmeAddress", oid: ".1.3.6.1.4.1.99999.1.3", base: "IpAddress"},
+		{name: "ACME-MIB::acmeLoad", oid: ".1.3.6.1.4.1.99999.1.4", base: "INTEGER"},
+		{name: "ACME-MIB::acmeFailed", oid: ".1.3.6.1.4.1.99999.0.3"},
+		{name: "RFC1213-MIB::mib-2", oid: ".1.3.6.1.2.1"},
+	}
+	for _, tt := range tests {
+		n := tree.Node(tt.name)
+		if n == nil || oids.Format(n.OID) != tt.oid || n.BaseType() != tt.base || !reflect.DeepEqual(n.Enums(), tt.enums) {
+			t.Errorf("Node(%s) = %+v", tt.name, n)
+		}
+	}
+	if desc := tree.Node("acmeName").Description; desc != `The name, which may contain "quotes".` {
+		t.Errorf("acmeName description = %q", desc)
+	}
+	if trap := tree.Node("acmeFailed"); trap.Kind != KindTrapType || len(trap.Objects) != 2 {
+		t.Errorf("acmeFailed = %+v", trap)
+	}
+}
+
+func TestLoadErrors(t *testing.T) {
+	tree := NewTree("testdata")
+	if err := tree.Load("NO-SUCH-MIB"); !errors.Is(err, ErrModuleNotFound) {
+		t.Errorf("Load() err = %v, want ErrModuleNotFound", err)
+	}
+	if err := NewTree("testdata/missing").Load("IF-MIB"); !errors.Is(err, ErrModuleNotFound) || !errors.Is(err, fs.ErrNotExist) {
+		t.Errorf("Load() from missing directory err = %v, want ErrModuleNotFound and fs.ErrNotExist", err)
+	}
+	// A missing directory doesn't stop the others being used, on the first Load or later ones
+	tree = NewTree("testdata/missing", "testdata")
+	for _, name := range []string{"SNMPv2-MIB", "IF-MIB"} {
+		if err := tree.Load(name); err != nil || tree.Module(name) == nil {
+			t.Errorf("Load(%s) with a missing directory err = %v", name, err)
+		}
+	}
+	tests := []struct {
+		name    string
+		src     string
+		wantErr error
+	}{
+		{name: "No BEGIN", src: "A-MIB DEFINITIONS ::= \nfoo OBJECT IDENTIFIER ::= { iso 3 }\nEND", wantErr: ErrSyntax},
+		{name: "No END", src: "A-MIB DEFINITIONS ::= BEGIN\nfoo OBJECT IDENTIFIER ::= { iso 3 }\n", wantErr: ErrSyntax},
+		{name: "Bad sub-identifier", src: "A-MIB DEFINITIONS ::= BEGIN\nfoo OBJECT IDENTIFIER ::= { iso 3 x }\nEND", wantErr: ErrSyntax},
+		{name: "Unterminated string", src: "A-MIB DEFINITIONS ::= BEGIN\nfoo OBJECT-TYPE\nDESCRIPTION \"x\n::= { iso 3 }\nEND", wantErr: ErrSyntax},
+		{name: "Bad enum", src: "A-MIB DEFINITIONS ::= BEGIN\nfoo OBJECT-TYPE SYNTAX INTEGER { a(x) } ::= { iso 3 }\nEND", wantErr: ErrSyntax},
+		{name: "Unknown parent", src: "A-MIB DEFINITIONS ::= BEGIN\nfoo OBJECT IDENTIFIER ::= { bar 3 }\nEND", wantErr: ErrUnknownSymbol},
+		{name: "Unknown type", src: "A-MIB DEFINITIONS ::= BEGIN\nfoo OBJECT-TYPE SYNTAX Bar ::= { iso 3 }\nEND", wantErr: ErrUnknownSymbol},
+		{name: "Loop", src: "A-MIB DEFINITIONS ::= BEGIN\na OBJECT IDENTIFIER ::= { b 1 }\nb OBJECT IDENTIFIER ::= { a 1 }\nEND", wantErr: ErrSyntax},
+		{name: "Comments", src: "-- header\nA-MIB DEFINITIONS ::= BEGIN -- inline -- foo OBJECT IDENTIFIER ::= { iso 3 }\nEND"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			modules, err := Parse(strings.NewReader(tt.src))
+			if err == nil {
+				tree := NewTree()
+				for _, m := range modules {
+					tree.add(m)
+				}
+				err = tree.resolve()
+			}
+			if !errors.Is(err, tt.wantErr) || (tt.wantErr == nil && err != nil) {
+				t.Errorf("err = %v, want %v", err, tt.wantErr)
+			}
+			if errors.Is(err, ErrSyntax) && !strings.Contains(err.Error(), "line ") && tt.name != "Loop" {
+				t.Errorf("err = %v has no line number", err)
+			}
+		})
+	}
+}
@@ -0,0 +1,514 @@
+package mib
+
+import (
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// The macros which assign an OID, and the kind of node they define
+var macroKinds = map[string]Kind{
+	"MODULE-IDENTITY":    KindModuleIdentity,
+	"OBJECT-IDENTITY":    KindObjectIdentity,
+	"OBJECT-TYPE":        KindObjectType,
+	"NOTIFICATION-TYPE":  KindNotificationType,
+	"TRAP-TYPE":          KindTrapType,
+	"OBJECT-GROUP":       KindGroup,
+	"NOTIFICATION-GROUP": KindGroup,
+	"MODULE-COMPLIANCE":  KindCompliance,
+	"AGENT-CAPABILITIES": KindCapabilities,
+}
+
+/*
+Parse reads the MIB modules in r, of which there is usually one per file.  The modules are only parsed, so
+the OIDs of the nodes are not set; use a Tree to resolve them.  Errors wrap ErrSyntax and include the line
+number.
+*/
+func Parse(r io.Reader) ([]*Module, error) {
+	src, err := io.ReadAll(r)
+	if err != nil {
+		return nil, err
+	}
+	tokens, err := tokenize(string(src))
+	if err != nil {
+		return nil, err
+	}
+	p := parser{tokens: tokens}
+	var modules []*Module
+	for !p.done() {
+		m, err := p.module()
+		if err != nil {
+			return modules, err
+		}
+		modules = append(modules, m)
+	}
+	return modules, nil
+}
+
+// parser works through the tokens of a MIB file
+type parser struct {
+	tokens []token
+	pos    int
+}
+
+func (p *parser) done() bool {
+	return p.pos >= len(p.tokens)
+}
+
+// peek returns the text of the token n places ahead, or "" past the end
+func (p *parser) peek(n int) string {
+	if p.pos+n < len(p.tokens) {
+		return p.tokens[p.pos+n].text
+	}
+	return ""
+}
+
+// next returns the next token, failing at the end of the source
+func (p *parser) next() (token, error) {
+	if p.done() {
+		return token{}, p.errorf("unexpected end of file")
+	}
+	p.pos++
+	return p.tokens[p.pos-1], nil
+}
+
+// expect consumes the next token, which must be text
+func (p *parser) expect(text string) error {
+	tok, err := p.next()
+	if err == nil && (tok.text != text || tok.quoted) {
+		p.pos--
+		err = p.errorf("expected %s, found %q", text, tok.text)
+	}
+	return err
+}
+
+// str consumes a quoted string
+func (p *parser) str() (string, error) {
+	tok, err := p.next()
+	if err == nil && !tok.quoted {
+		p.pos--
+		err = p.errorf("expected a quoted string, found %q", tok.text)
+	}
+	return tok.text, err
+}
+
+func (p *parser) errorf(format string, args ...interface{}) error {
+	line := 0
+	if len(p.tokens) > 0 {
+		line = p.tokens[min(p.pos, len(p.tokens)-1)].line
+	}
+	return fmt.Errorf("line %d: %w: %s", line, ErrSyntax, fmt.Sprintf(format, args...))
+}
+
+// module parses "NAME DEFINITIONS ::= BEGIN ... END"
+func (p *parser) module() (*Module, error) {
+	name, err := p.next()
+	if err != nil {
+		return nil, err
+	}
+	m := &Module{Name: name.text, Imports: map[string]string{}, nodes: map[string]*Node{},
+		types: map[string]*TextualConvention{}}
+	if p.peek(0) == "{" {
+		// The module's own OID, rarely given
+		if err = p.skipBalanced(); err != nil {
+			return nil, err
+		}
+	}
+	for _, text := range []string{"DEFINITIONS", "::=", "BEGIN"} {
+		if err = p.expect(text); err != nil {
+			return nil, err
+		}
+	}
+	for {
+		tok, err := p.next()
+		if err != nil {
+			return nil, err
+		}
+		switch {
+		case tok.text == "END":
+			return m, nil
+		case tok.text == "IMPORTS":
+			err = p.imports(m)
+		case tok.text == "EXPORTS":
+			err = p.skipTo(";")
+		case p.peek(0) == "MACRO":
+			err = p.skipTo("END")
+		case p.peek(0) == "::=":
+			p.pos++
+			err = p.typeAssignment(m, tok)
+		default:
+			err = p.valueAssignment(m, tok)
+		}
+		if err != nil {
+			return nil, err
+		}
+	}
+}
+
+// imports parses "sym, sym FROM MODULE sym FROM MODULE ;"
+func (p *parser) imports(m *Module) error {
+	var symbols []string
+	for {
+		tok, err := p.next()
+		if err != nil {
+			return err
+		}
+		switch tok.text {
+		case ";":
+			return nil
+		case ",":
+		case "FROM":
+			from, err := p.next()
+			if err != nil {
+				return err
+			}
+			for _, s := range symbols {
+				m.Imports[s] = from.text
+			}
+			symbols = symbols[:0]
+		default:
+			symbols = append(symbols, tok.text)
+		}
+	}
+}
+
+// typeAssignment parses a TEXTUAL-CONVENTION or plain type after "Name ::="
+func (p *parser) typeAssignment(m *Module, name token) error {
+	tc := &TextualConvention{Name: name.text, Module: m.Name, line: name.line}
+	if p.peek(0) == "TEXTUAL-CONVENTION" {
+		p.pos++
+		n := &Node{}
+		if err := p.clauses(n, tc, ""); err != nil {
+			return err
+		}
+		tc.Syntax, tc.Status, tc.Description = n.Syntax, n.Status, n.Description
+		if tc.Syntax == nil {
+			return p.errorf("textual convention %s has no SYNTAX", name.text)
+		}
+	} else {
+		var err error
+		if tc.Syntax, err = p.syntax(); err != nil {
+			return err
+		}
+	}
+	if _, ok := m.types[tc.Name]; !ok {
+		m.types[tc.Name] = tc
+		m.Types = append(m.Types, tc)
+	}
+	return nil
+}
+
+// valueAssignment parses "name OBJECT IDENTIFIER ::= {...}" or "name MACRO clauses ::= {...}"
+func (p *parser) valueAssignment(m *Module, name token) error {
+	n := &Node{Name: name.text, Module: m.Name, line: name.line}
+	macro, err := p.next()
+	if err != nil {
+		return err
+	}
+	switch {
+	case macro.text == "OBJECT" && p.peek(0) == "IDENTIFIER":
+		p.pos++
+		n.Kind = KindObjectIdentifier
+		err = p.expect("::=")
+	case isMacro(macro.text):
+		n.Kind = macroKinds[macro.text]
+		err = p.clauses(n, nil, "::=")
+		if err == nil {
+			err = p.expect("::=")
+		}
+		if n.Kind != KindObjectType {
+			// Such as a SYNTAX refinement within a MODULE-COMPLIANCE
+			n.Syntax = nil
+		}
+	default:
+		// Some other value assignment, such as "maxValue INTEGER ::= 10", which has no OID
+		if err = p.skipTo("::="); err != nil {
+			return err
+		}
+		p.pos++
+		if p.peek(0) == "{" {
+			return p.skipBalanced()
+		}
+		_, err = p.next()
+		return err
+	}
+	if err != nil {
+		return err
+	}
+	if n.Kind == KindTrapType {
+		// The value is a number, and the OID is ENTERPRISE.0.number
+		tok, err := p.next()
+		if err != nil {
+			return err
+		}
+		num, err := strconv.ParseUint(tok.text, 10, 32)
+		if err != nil || len(n.parent) == 0 {
+			return p.errorf("invalid TRAP-TYPE %s", n.Name)
+		}
+		n.subs = []subID{{num: 0}, {num: uint32(num)}}
+	} else if err = p.oidValue(n); err != nil {
+		return err
+	}
+	if _, ok := m.nodes[n.Name]; !ok {
+		m.nodes[n.Name] = n
+		m.Nodes = append(m.Nodes, n)
+	}
+	return nil
+}
+
+// isMacro reports whether the word is one of the macros assigning an OID
+func isMacro(word string) bool {
+	_, ok := macroKinds[word]
+	return ok
+}
+
+// oidValue parses an OID value such as "{ ifEntry 2 }" or "{ iso org(3) dod(6) 1 }"
+func (p *parser) oidValue(n *Node) error {
+	if err := p.expect("{"); err != nil {
+		return err
+	}
+	for first := true; ; first = false {
+		tok, err := p.next()
+		if err != nil {
+			return err
+		}
+		if tok.text == "}" {
+			if first {
+				return p.errorf("empty OID value for %s", n.Name)
+			}
+			return nil
+		}
+		if num, err := strconv.ParseUint(tok.text, 10, 32); err == nil {
+			n.subs = append(n.subs, subID{num: uint32(num)})
+			continue
+		}
+		if p.peek(0) == "(" {
+			// Named number such as dod(6)
+			p.pos++
+			numTok, err := p.next()
+			if err != nil {
+				return err
+			}
+			num, err := strconv.ParseUint(numTok.text, 10, 32)
+			if err != nil {
+				return p.errorf("invalid sub-identifier %q", numTok.text)
+			}
+			if err = p.expect(")"); err != nil {
+				return err
+			}
+			// When first, such as { iso(1) 3 }, the OID starts from the root
+			n.subs = append(n.subs, subID{name: tok.text, num: uint32(num)})
+			continue
+		}
+		if !first {
+			return p.errorf("invalid sub-identifier %q", tok.text)
+		}
+		n.parent = tok.text
+	}
+}
+
+// clauses parses the clauses of a macro, up to but not including the stop token.  The node or textual
+// convention (which may be nil) receive the values.
+func (p *parser) clauses(n *Node, tc *TextualConvention, stop string) error {
+	for !p.done() && p.peek(0) != stop {
+		tok, _ := p.next()
+		if tok.quoted {
+			// The value of a clause which isn't kept, such as LAST-UPDATED
+			continue
+		}
+		var err error
+		switch tok.text {
+		case "SYNTAX":
+			// Only the first is kept, later ones being within a MODULE-COMPLIANCE or similar
+			var s *Syntax
+			if s, err = p.syntax(); n.Syntax == nil {
+				n.Syntax = s
+			}
+			if tc != nil {
+				// The SYNTAX ends a TEXTUAL-CONVENTION
+				return err
+			}
+		case "DISPLAY-HINT":
+			var hint string
+			if hint, err = p.str(); tc != nil {
+				tc.DisplayHint = hint
+			}
+		case "UNITS":
+			n.Units, err = p.str()
+		case "DESCRIPTION":
+			var desc string
+			if desc, err = p.str(); len(n.Description) == 0 {
+				n.Description = desc
+			}
+		case "REVISION":
+			// A revision has its own DESCRIPTION, which mustn't replace that of the node
+			if _, err = p.str(); err == nil && p.peek(0) == "DESCRIPTION" {
+				p.pos++
+				_, err = p.str()
+			}
+		case "MAX-ACCESS", "ACCESS":
+			var access token
+			access, err = p.next()
+			n.Access = access.text
+		case "STATUS":
+			var status token
+			status, err = p.next()
+			n.Status = status.text
+		case "INDEX":
+			n.Index, err = p.nameList()
+		case "AUGMENTS":
+			var names []string
+			if names, err = p.nameList(); err == nil && len(names) > 0 {
+				n.Augments = names[0]
+			}
+		case "OBJECTS", "VARIABLES", "NOTIFICATIONS":
+			n.Objects, err = p.nameList()
+		case "ENTERPRISE":
+			var enterprise token
+			enterprise, err = p.next()
+			n.parent = enterprise.text
+		case "{", "(":
+			p.pos--
+			err = p.skipBalanced()
+		}
+		if err != nil {
+			return err
+		}
+	}
+	if len(stop) > 0 && p.done() {
+		return p.errorf("expected %s", stop)
+	}
+	return nil
+}
+
+// nameList parses "{ name, name }", dropping any IMPLIED
+func (p *parser) nameList() ([]string, error) {
+	if err := p.expect("{"); err != nil {
+		return nil, err
+	}
+	var names []string
+	for {
+		tok, err := p.next()
+		if err != nil {
+			return nil, err
+		}
+		switch tok.text {
+		case "}":
+			return names, nil
+		case ",", "IMPLIED":
+		default:
+			names = append(names, tok.text)
+		}
+	}
+}
+
+// syntax parses a type, such as "INTEGER { up(1), down(2) }" or "OCTET STRING (SIZE (0..255))"
+func (p *parser) syntax() (*Syntax, error) {
+	if p.peek(0) == "[" {
+		// Tagged type such as "[APPLICATION 2] IMPLICIT INTEGER"
+		if err := p.skipTo("]"); err != nil {
+			return nil, err
+		}
+		p.pos++
+		if p.peek(0) == "IMPLICIT" {
+			p.pos++
+		}
+	}
+	tok, err := p.next()
+	if err != nil {
+		return nil, err
+	}
+	s := &Syntax{Type: tok.text}
+	switch {
+	case tok.text == "OCTET" && p.peek(0) == "STRING", tok.text == "OBJECT" && p.peek(0) == "IDENTIFIER":
+		s.Type += " " + p.peek(0)
+		p.pos++
+	case tok.text == "SEQUENCE" && p.peek(0) == "OF":
+		p.pos++
+		of, err := p.next()
+		if err != nil {
+			return nil, err
+		}
+		s.Type = "SEQUENCE OF " + of.text
+	case tok.text == "SEQUENCE" || tok.text == "CHOICE":
+		err = p.skipBalanced()
+	case p.peek(0) == "{":
+		s.Enums, err = p.enums()
+	}
+	if err == nil && p.peek(0) == "(" {
+		err = p.skipBalanced()
+	}
+	return s, err
+}
+
+// enums parses "{ up(1), down(2) }"
+func (p *parser) enums() ([]Enum, error) {
+	if err := p.expect("{"); err != nil {
+		return nil, err
+	}
+	var enums []Enum
+	for {
+		tok, err := p.next()
+		if err != nil {
+			return nil, err
+		}
+		switch tok.text {
+		case "}":
+			return enums, nil
+		case ",":
+			continue
+		}
+		if err = p.expect("("); err != nil {
+			return nil, err
+		}
+		num, err := p.next()
+		if err != nil {
+			return nil, err
+		}
+		value, err := strconv.ParseInt(num.text, 10, 64)
+		if err != nil {
+			return nil, p.errorf("invalid value %q for %s", num.text, tok.text)
+		}
+		if err = p.expect(")"); err != nil {
+			return nil, err
+		}
+		enums = append(enums, Enum{Name: tok.text, Value: value})
+	}
+}
+
+// skipBalanced skips a bracketed group starting at the next token, including any nested groups
+func (p *parser) skipBalanced() error {
+	depth := 0
+	for {
+		tok, err := p.next()
+		if err != nil {
+			return err
+		}
+		if tok.quoted {
+			continue
+		}
+		switch tok.text {
+		case "{", "(":
+			depth++
+		case "}", ")":
+			depth--
+		}
+		if depth <= 0 {
+			return nil
+		}
+	}
+}
+
+// skipTo skips tokens up to the given one, leaving it to be read next unless it is ";" or "END"
+func (p *parser) skipTo(text string) error {
+	for !p.done() {
+		if tok := p.tokens[p.pos]; tok.text == text && !tok.quoted {
+			if text == ";" || text == "END" {
+				p.pos++
+			}
+			return nil
+		}
+		p.pos++
+	}
+	return p.errorf("expected %s", text)
+}
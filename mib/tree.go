@@ -0,0 +1,390 @@
+package mib
+
+import (
+	"errors"
+	"fmt"
+	"github.com/jjcinaz/gosnmpHelper/internal/oids"
+	"os"
+	"path/filepath"
+	"regexp"
+	"strings"
+)
+
+// The start of a module, giving its name
+var definitionsRx = regexp.MustCompile(`(?m)^[ \t]*([A-Za-z][\w-]*)[ \t\r\n]*(?:\{[^}]*\}[ \t\r\n]*)?DEFINITIONS[ \t\r\n]*::=[ \t\r\n]*BEGIN`)
+
+// The roots of the OID tree, which every module may use without importing
+var rootNodes = map[string]*Node{
+	"ccitt":           {Name: "ccitt", OID: []uint32{0}},
+	"iso":             {Name: "iso", OID: []uint32{1}},
+	"joint-iso-ccitt": {Name: "joint-iso-ccitt", OID: []uint32{2}},
+}
+
+/*
+A Tree holds the MIB modules loaded from a set of directories, and resolves names to OIDs and back.  Names
+which a module uses without importing are looked up in the other loaded modules, as net-snmp does, since
+many MIBs in the wild have incomplete IMPORTS.
+
+A Tree is not safe for concurrent use while modules are being loaded.
+*/
+type Tree struct {
+	dirs    []string
+	files   map[string]string // module name to file, filled in when first needed
+	skipped error             // the directories and files which couldn't be read while indexing
+	modules map[string]*Module
+	order   []*Module // in the order loaded
+	byOID   map[string]*Node
+}
+
+// NewTree returns a Tree which loads modules from the MIB files in dirs.  The built-in modules are loaded.
+// Directories and files which can't be read are skipped, as net-snmp does.
+func NewTree(dirs ...string) *Tree {
+	t := &Tree{dirs: dirs, modules: map[string]*Module{}, byOID: map[string]*Node{}}
+	for _, name := range builtinOrder {
+		modules, err := Parse(strings.NewReader(builtinModules[name]))
+		if err != nil {
+			panic(fmt.Sprintf("built-in module %s: %v", name, err))
+		}
+		t.add(modules[0])
+	}
+	if err := t.resolve(); err != nil {
+		panic(fmt.Sprintf("built-in modules: %v", err))
+	}
+	return t
+}
+
+/*
+Load loads the named modules, such as "IF-MIB", along with the modules they import, from the directories
+given to NewTree().  Modules already loaded are skipped.  An error wrapping ErrModuleNotFound is returned if
+any module cannot be found, ErrSyntax if a file cannot be parsed, or ErrUnknownSymbol for names which
+cannot be resolved.  The modules and nodes which could be loaded remain usable after an error.
+*/
+func (t *Tree) Load(names ...string) error {
+	var errs []error
+	for _, name := range names {
+		if err := t.load(name); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if err := t.resolve(); err != nil {
+		errs = append(errs, err)
+	}
+	return errors.Join(errs...)
+}
+
+// LoadFile loads all the modules in the named file, along with the modules they import.  See Load().
+func (t *Tree) LoadFile(filename string) error {
+	modules, err := parseFile(filename)
+	if err != nil {
+		return err
+	}
+	var errs []error
+	for _, m := range modules {
+		if t.modules[m.Name] != nil {
+			continue
+		}
+		t.add(m)
+		if err = t.loadImports(m); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	if err = t.resolve(); err != nil {
+		errs = append(errs, err)
+	}
+	return errors.Join(errs...)
+}
+
+// Module returns the loaded module of the given name, or nil
+func (t *Tree) Module(name string) *Module {
+	return t.modules[name]
+}
+
+// Modules returns the loaded modules, including those built in, in the order they were loaded
+func (t *Tree) Modules() []*Module {
+	return append([]*Module{}, t.order...)
+}
+
+/*
+Node returns the node for a name such as "IF-MIB::ifDescr", or nil if there is none.  Without a module
+name, as in "ifDescr", the modules are searched in the order they were loaded.
+*/
+func (t *Tree) Node(name string) *Node {
+	if module, object, ok := strings.Cut(name, "::"); ok {
+		if m := t.modules[module]; m != nil {
+			return m.nodes[object]
+		}
+		return nil
+	}
+	for _, m := range t.order {
+		if n := m.nodes[name]; n != nil && n.OID != nil {
+			return n
+		}
+	}
+	return nil
+}
+
+/*
+Resolve returns the OID of a name such as "IF-MIB::ifDescr.6" or "ifDescr.6", where the sub-identifiers
+after the object name are optional.  Numeric OIDs are accepted as they are.  An error wrapping
+ErrUnknownSymbol is returned for unknown names.
+*/
+func (t *Tree) Resolve(name string) ([]uint32, error) {
+	name = strings.TrimSpace(name)
+	if oid, err := oids.Parse(name); err == nil {
+		return oid, nil
+	}
+	object, subs := name, ""
+	if i := strings.Index(name, "::"); i >= 0 {
+		if j := strings.IndexByte(name[i+2:], '.'); j >= 0 {
+			object, subs = name[:i+2+j], name[i+3+j:]
+		}
+	} else {
+		object, subs, _ = strings.Cut(name, ".")
+	}
+	n := t.Node(object)
+	if n == nil || n.OID == nil {
+		return nil, fmt.Errorf("%w %q", ErrUnknownSymbol, name)
+	}
+	oid := append([]uint32{}, n.OID...)
+	if len(subs) > 0 {
+		suffix, err := oids.Parse(subs)
+		if err != nil {
+			return nil, fmt.Errorf("%w: invalid sub-identifiers in %q", ErrUnknownSymbol, name)
+		}
+		oid = append(oid, suffix...)
+	}
+	return oid, nil
+}
+
+// Lookup returns the node with the longest OID which is a prefix of oid, along with the remaining
+// sub-identifiers, such as the instance index.  The node is nil if no loaded module covers the OID.
+func (t *Tree) Lookup(oid []uint32) (*Node, []uint32) {
+	for i := len(oid); i > 0; i-- {
+		if n := t.byOID[oids.Format(oid[:i])]; n != nil {
+			return n, oid[i:]
+		}
+	}
+	return nil, oid
+}
+
+// Format returns the OID as a name such as "IF-MIB::ifDescr.6", or in numeric form if no loaded module
+// covers it
+func (t *Tree) Format(oid []uint32) string {
+	n, rest := t.Lookup(oid)
+	if n == nil || len(n.Module) == 0 {
+		return oids.Format(oid)
+	}
+	if len(rest) == 0 {
+		return n.Module + "::" + n.Name
+	}
+	return n.Module + "::" + n.Name + oids.Format(rest)
+}
+
+// load loads the named module, unless already loaded, and the modules it imports
+func (t *Tree) load(name string) error {
+	if t.modules[name] != nil {
+		return nil
+	}
+	filename, err := t.find(name)
+	if err != nil {
+		return err
+	}
+	modules, err := parseFile(filename)
+	if err != nil {
+		return err
+	}
+	var errs []error
+	for _, m := range modules {
+		if t.modules[m.Name] == nil {
+			t.add(m)
+			if err = t.loadImports(m); err != nil {
+				errs = append(errs, err)
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// loadImports loads the modules imported by m
+func (t *Tree) loadImports(m *Module) error {
+	var errs []error
+	seen := map[string]bool{}
+	for _, from := range m.Imports {
+		if seen[from] {
+			continue
+		}
+		seen[from] = true
+		if err := t.load(from); err != nil {
+			errs = append(errs, fmt.Errorf("%s imports: %w", m.Name, err))
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// add records a parsed module as loaded
+func (t *Tree) add(m *Module) {
+	t.modules[m.Name] = m
+	t.order = append(t.order, m)
+}
+
+// find returns the file which declares the named module.  The directories are indexed the first time.  If
+// the module isn't found the errors for any directories and files which couldn't be read are included.
+func (t *Tree) find(name string) (string, error) {
+	if t.files == nil {
+		t.index()
+	}
+	if filename, ok := t.files[name]; ok {
+		return filename, nil
+	}
+	return "", errors.Join(fmt.Errorf("%w: %s", ErrModuleNotFound, name), t.skipped)
+}
+
+// index records the file declaring each module in the directories, skipping those which can't be read
+func (t *Tree) index() {
+	files := map[string]string{}
+	var errs []error
+	for _, dir := range t.dirs {
+		entries, err := os.ReadDir(dir)
+		if err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		for _, e := range entries {
+			if e.IsDir() {
+				continue
+			}
+			filename := filepath.Join(dir, e.Name())
+			src, err := os.ReadFile(filename)
+			if err != nil {
+				errs = append(errs, err)
+				continue
+			}
+			for _, match := range definitionsRx.FindAllSubmatch(src, -1) {
+				if _, ok := files[string(match[1])]; !ok {
+					files[string(match[1])] = filename
+				}
+			}
+		}
+	}
+	t.files, t.skipped = files, errors.Join(errs...)
+}
+
+// parseFile parses the modules in the named file
+func parseFile(filename string) ([]*Module, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	modules, err := Parse(f)
+	if err != nil {
+		return nil, fmt.Errorf("%s: %w", filename, err)
+	}
+	return modules, nil
+}
+
+// resolve sets the OIDs of the nodes, and the textual conventions of the syntaxes, of all modules not yet
+// resolved
+func (t *Tree) resolve() error {
+	var errs []error
+	for _, m := range t.order {
+		if m.resolved {
+			continue
+		}
+		m.resolved = true
+		for _, n := range m.Nodes {
+			if err := t.resolveNode(m, n, map[*Node]bool{}); err != nil {
+				errs = append(errs, err)
+			}
+		}
+		for _, tc := range m.Types {
+			if !isBaseType(tc.Syntax.Type) {
+				tc.Parent = t.lookupType(m, tc.Syntax.Type)
+				if tc.Parent == nil || tc.Parent == tc {
+					tc.Parent = nil
+					errs = append(errs, fmt.Errorf("%s::%s: %w %s", m.Name, tc.Name, ErrUnknownSymbol, tc.Syntax.Type))
+				}
+			}
+		}
+		for _, n := range m.Nodes {
+			if n.Syntax != nil && !isBaseType(n.Syntax.Type) {
+				if n.TC = t.lookupType(m, n.Syntax.Type); n.TC == nil {
+					errs = append(errs, fmt.Errorf("%s::%s: %w %s", m.Name, n.Name, ErrUnknownSymbol, n.Syntax.Type))
+				}
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+// resolveNode sets the OID of a node of module m, first resolving the node its value refers to
+func (t *Tree) resolveNode(m *Module, n *Node, visiting map[*Node]bool) error {
+	if n.OID != nil {
+		return nil
+	}
+	if visiting[n] {
+		return fmt.Errorf("%s::%s: %w: OID refers back to itself", m.Name, n.Name, ErrSyntax)
+	}
+	visiting[n] = true
+	var oid []uint32
+	if len(n.parent) > 0 {
+		pm, parent := t.lookup(m, n.parent)
+		if parent == nil {
+			return fmt.Errorf("%s::%s: %w %s", m.Name, n.Name, ErrUnknownSymbol, n.parent)
+		}
+		if err := t.resolveNode(pm, parent, visiting); err != nil {
+			return err
+		}
+		oid = append(oid, parent.OID...)
+	}
+	for _, sub := range n.subs {
+		oid = append(oid, sub.num)
+		if len(sub.name) > 0 {
+			// A named number, such as dod(6) in { iso org(3) dod(6) 1 }, also names that OID
+			if _, ok := t.byOID[oids.Format(oid)]; !ok {
+				t.byOID[oids.Format(oid)] = &Node{Name: sub.name, Module: m.Name, OID: append([]uint32{}, oid...)}
+			}
+		}
+	}
+	n.OID = oid
+	// The first module to define an OID names it
+	if _, ok := t.byOID[oids.Format(oid)]; !ok {
+		t.byOID[oids.Format(oid)] = n
+	}
+	return nil
+}
+
+// lookup finds a node named in module m, returning it and the module defining it
+func (t *Tree) lookup(m *Module, name string) (*Module, *Node) {
+	for depth := 0; depth < 8 && m != nil; depth++ {
+		if n := m.nodes[name]; n != nil {
+			return m, n
+		}
+		m = t.modules[m.Imports[name]]
+	}
+	if n := rootNodes[name]; n != nil {
+		return nil, n
+	}
+	for _, other := range t.order {
+		if n := other.nodes[name]; n != nil {
+			return other, n
+		}
+	}
+	return nil, nil
+}
+
+// lookupType finds a type named in module m
+func (t *Tree) lookupType(m *Module, name string) *TextualConvention {
+	for depth := 0; depth < 8 && m != nil; depth++ {
+		if tc := m.types[name]; tc != nil {
+			return tc
+		}
+		m = t.modules[m.Imports[name]]
+	}
+	for _, other := range t.order {
+		if tc := other.types[name]; tc != nil {
+			return tc
+		}
+	}
+	return nil
+}
@@ -0,0 +1,447 @@
+package gosnmpHelper
+
+// The names registered for each MIB module in the built-in symbol table
+var builtinNames = map[string]map[string]string{
+	"SNMPv2-MIB": {
+		"system":                  ".1.3.6.1.2.1.1",
+		"sysDescr":                ".1.3.6.1.2.1.1.1",
+		"sysObjectID":             ".1.3.6.1.2.1.1.2",
+		"sysUpTime":               ".1.3.6.1.2.1.1.3",
+		"sysContact":              ".1.3.6.1.2.1.1.4",
+		"sysName":                 ".1.3.6.1.2.1.1.5",
+		"sysLocation":             ".1.3.6.1.2.1.1.6",
+		"sysServices":             ".1.3.6.1.2.1.1.7",
+		"sysORLastChange":         ".1.3.6.1.2.1.1.8",
+		"sysORTable":              ".1.3.6.1.2.1.1.9",
+		"sysOREntry":              ".1.3.6.1.2.1.1.9.1",
+		"sysORIndex":              ".1.3.6.1.2.1.1.9.1.1",
+		"sysORID":                 ".1.3.6.1.2.1.1.9.1.2",
+		"sysORDescr":              ".1.3.6.1.2.1.1.9.1.3",
+		"sysORUpTime":             ".1.3.6.1.2.1.1.9.1.4",
+		"snmp":                    ".1.3.6.1.2.1.11",
+		"snmpInPkts":              ".1.3.6.1.2.1.11.1",
+		"snmpOutPkts":             ".1.3.6.1.2.1.11.2",
+		"snmpInBadVersions":       ".1.3.6.1.2.1.11.3",
+		"snmpInBadCommunityNames": ".1.3.6.1.2.1.11.4",
+		"snmpInBadCommunityUses":  ".1.3.6.1.2.1.11.5",
+		"snmpInASNParseErrs":      ".1.3.6.1.2.1.11.6",
+		"snmpInTooBigs":           ".1.3.6.1.2.1.11.8",
+		"snmpInNoSuchNames":       ".1.3.6.1.2.1.11.9",
+		"snmpInBadValues":         ".1.3.6.1.2.1.11.10",
+		"snmpInReadOnlys":         ".1.3.6.1.2.1.11.11",
+		"snmpInGenErrs":           ".1.3.6.1.2.1.11.12",
+		"snmpInTotalReqVars":      ".1.3.6.1.2.1.11.13",
+		"snmpInTotalSetVars":      ".1.3.6.1.2.1.11.14",
+		"snmpInGetRequests":       ".1.3.6.1.2.1.11.15",
+		"snmpInGetNexts":          ".1.3.6.1.2.1.11.16",
+		"snmpInSetRequests":       ".1.3.6.1.2.1.11.17",
+		"snmpInGetResponses":      ".1.3.6.1.2.1.11.18",
+		"snmpInTraps":             ".1.3.6.1.2.1.11.19",
+		"snmpOutTooBigs":          ".1.3.6.1.2.1.11.20",
+		"snmpOutNoSuchNames":      ".1.3.6.1.2.1.11.21",
+		"snmpOutBadValues":        ".1.3.6.1.2.1.11.22",
+		"snmpOutGenErrs":          ".1.3.6.1.2.1.11.24",
+		"snmpOutGetRequests":      ".1.3.6.1.2.1.11.25",
+		"snmpOutGetNexts":         ".1.3.6.1.2.1.11.26",
+		"snmpOutSetRequests":      ".1.3.6.1.2.1.11.27",
+		"snmpOutGetResponses":     ".1.3.6.1.2.1.11.28",
+		"snmpOutTraps":            ".1.3.6.1.2.1.11.29",
+		"snmpEnableAuthenTraps":   ".1.3.6.1.2.1.11.30",
+		"snmpSilentDrops":         ".1.3.6.1.2.1.11.31",
+		"snmpProxyDrops":          ".1.3.6.1.2.1.11.32",
+		"snmpTrapOID":             ".1.3.6.1.6.3.1.1.4.1",
+		"snmpTrapEnterprise":      ".1.3.6.1.6.3.1.1.4.3",
+		"coldStart":               ".1.3.6.1.6.3.1.1.5.1",
+		"warmStart":               ".1.3.6.1.6.3.1.1.5.2",
+		"authenticationFailure":   ".1.3.6.1.6.3.1.1.5.5",
+		"snmpSetSerialNo":         ".1.3.6.1.6.3.1.1.6.1",
+	},
+	"IF-MIB": {
+		"interfaces":                 ".1.3.6.1.2.1.2",
+		"ifNumber":                   ".1.3.6.1.2.1.2.1",
+		"ifTable":                    ".1.3.6.1.2.1.2.2",
+		"ifEntry":                    ".1.3.6.1.2.1.2.2.1",
+		"ifIndex":                    ".1.3.6.1.2.1.2.2.1.1",
+		"ifDescr":                    ".1.3.6.1.2.1.2.2.1.2",
+		"ifType":                     ".1.3.6.1.2.1.2.2.1.3",
+		"ifMtu":                      ".1.3.6.1.2.1.2.2.1.4",
+		"ifSpeed":                    ".1.3.6.1.2.1.2.2.1.5",
+		"ifPhysAddress":              ".1.3.6.1.2.1.2.2.1.6",
+		"ifAdminStatus":              ".1.3.6.1.2.1.2.2.1.7",
+		"ifOperStatus":               ".1.3.6.1.2.1.2.2.1.8",
+		"ifLastChange":               ".1.3.6.1.2.1.2.2.1.9",
+		"ifInOctets":                 ".1.3.6.1.2.1.2.2.1.10",
+		"ifInUcastPkts":              ".1.3.6.1.2.1.2.2.1.11",
+		"ifInNUcastPkts":             ".1.3.6.1.2.1.2.2.1.12",
+		"ifInDiscards":               ".1.3.6.1.2.1.2.2.1.13",
+		"ifInErrors":                 ".1.3.6.1.2.1.2.2.1.14",
+		"ifInUnknownProtos":          ".1.3.6.1.2.1.2.2.1.15",
+		"ifOutOctets":                ".1.3.6.1.2.1.2.2.1.16",
+		"ifOutUcastPkts":             ".1.3.6.1.2.1.2.2.1.17",
+		"ifOutNUcastPkts":            ".1.3.6.1.2.1.2.2.1.18",
+		"ifOutDiscards":              ".1.3.6.1.2.1.2.2.1.19",
+		"ifOutErrors":                ".1.3.6.1.2.1.2.2.1.20",
+		"ifOutQLen":                  ".1.3.6.1.2.1.2.2.1.21",
+		"ifSpecific":                 ".1.3.6.1.2.1.2.2.1.22",
+		"ifMIB":                      ".1.3.6.1.2.1.31",
+		"ifMIBObjects":               ".1.3.6.1.2.1.31.1",
+		"ifXTable":                   ".1.3.6.1.2.1.31.1.1",
+		"ifXEntry":                   ".1.3.6.1.2.1.31.1.1.1",
+		"ifName":                     ".1.3.6.1.2.1.31.1.1.1.1",
+		"ifInMulticastPkts":          ".1.3.6.1.2.1.31.1.1.1.2",
+		"ifInBroadcastPkts":          ".1.3.6.1.2.1.31.1.1.1.3",
+		"ifOutMulticastPkts":         ".1.3.6.1.2.1.31.1.1.1.4",
+		"ifOutBroadcastPkts":         ".1.3.6.1.2.1.31.1.1.1.5",
+		"ifHCInOctets":               ".1.3.6.1.2.1.31.1.1.1.6",
+		"ifHCInUcastPkts":            ".1.3.6.1.2.1.31.1.1.1.7",
+		"ifHCInMulticastPkts":        ".1.3.6.1.2.1.31.1.1.1.8",
+		"ifHCInBroadcastPkts":        ".1.3.6.1.2.1.31.1.1.1.9",
+		"ifHCOutOctets":              ".1.3.6.1.2.1.31.1.1.1.10",
+		"ifHCOutUcastPkts":           ".1.3.6.1.2.1.31.1.1.1.11",
+		"ifHCOutMulticastPkts":       ".1.3.6.1.2.1.31.1.1.1.12",
+		"ifHCOutBroadcastPkts":       ".1.3.6.1.2.1.31.1.1.1.13",
+		"ifLinkUpDownTrapEnable":     ".1.3.6.1.2.1.31.1.1.1.14",
+		"ifHighSpeed":                ".1.3.6.1.2.1.31.1.1.1.15",
+		"ifPromiscuousMode":          ".1.3.6.1.2.1.31.1.1.1.16",
+		"ifConnectorPresent":         ".1.3.6.1.2.1.31.1.1.1.17",
+		"ifAlias":                    ".1.3.6.1.2.1.31.1.1.1.18",
+		"ifCounterDiscontinuityTime": ".1.3.6.1.2.1.31.1.1.1.19",
+		"ifStackTable":               ".1.3.6.1.2.1.31.1.2",
+		"ifStackEntry":               ".1.3.6.1.2.1.31.1.2.1",
+		"ifStackHigherLayer":         ".1.3.6.1.2.1.31.1.2.1.1",
+		"ifStackLowerLayer":          ".1.3.6.1.2.1.31.1.2.1.2",
+		"ifStackStatus":              ".1.3.6.1.2.1.31.1.2.1.3",
+		"ifRcvAddressTable":          ".1.3.6.1.2.1.31.1.4",
+		"ifRcvAddressEntry":          ".1.3.6.1.2.1.31.1.4.1",
+		"ifRcvAddressAddress":        ".1.3.6.1.2.1.31.1.4.1.1",
+		"ifRcvAddressStatus":         ".1.3.6.1.2.1.31.1.4.1.2",
+		"ifRcvAddressType":           ".1.3.6.1.2.1.31.1.4.1.3",
+		"ifTableLastChange":          ".1.3.6.1.2.1.31.1.5",
+		"ifStackLastChange":          ".1.3.6.1.2.1.31.1.6",
+		"linkDown":                   ".1.3.6.1.6.3.1.1.5.3",
+		"linkUp":                     ".1.3.6.1.6.3.1.1.5.4",
+	},
+	"IP-MIB": {
+		"ip":                                  ".1.3.6.1.2.1.4",
+		"ipForwarding":                        ".1.3.6.1.2.1.4.1",
+		"ipDefaultTTL":                        ".1.3.6.1.2.1.4.2",
+		"ipInReceives":                        ".1.3.6.1.2.1.4.3",
+		"ipInHdrErrors":                       ".1.3.6.1.2.1.4.4",
+		"ipInAddrErrors":                      ".1.3.6.1.2.1.4.5",
+		"ipForwDatagrams":                     ".1.3.6.1.2.1.4.6",
+		"ipInUnknownProtos":                   ".1.3.6.1.2.1.4.7",
+		"ipInDiscards":                        ".1.3.6.1.2.1.4.8",
+		"ipInDelivers":                        ".1.3.6.1.2.1.4.9",
+		"ipOutRequests":                       ".1.3.6.1.2.1.4.10",
+		"ipOutDiscards":                       ".1.3.6.1.2.1.4.11",
+		"ipOutNoRoutes":                       ".1.3.6.1.2.1.4.12",
+		"ipReasmTimeout":                      ".1.3.6.1.2.1.4.13",
+		"ipReasmReqds":                        ".1.3.6.1.2.1.4.14",
+		"ipReasmOKs":                          ".1.3.6.1.2.1.4.15",
+		"ipReasmFails":                        ".1.3.6.1.2.1.4.16",
+		"ipFragOKs":                           ".1.3.6.1.2.1.4.17",
+		"ipFragFails":                         ".1.3.6.1.2.1.4.18",
+		"ipFragCreates":                       ".1.3.6.1.2.1.4.19",
+		"ipAddrTable":                         ".1.3.6.1.2.1.4.20",
+		"ipAddrEntry":                         ".1.3.6.1.2.1.4.20.1",
+		"ipAdEntAddr":                         ".1.3.6.1.2.1.4.20.1.1",
+		"ipAdEntIfIndex":                      ".1.3.6.1.2.1.4.20.1.2",
+		"ipAdEntNetMask":                      ".1.3.6.1.2.1.4.20.1.3",
+		"ipAdEntBcastAddr":                    ".1.3.6.1.2.1.4.20.1.4",
+		"ipAdEntReasmMaxSize":                 ".1.3.6.1.2.1.4.20.1.5",
+		"ipNetToMediaTable":                   ".1.3.6.1.2.1.4.22",
+		"ipNetToMediaEntry":                   ".1.3.6.1.2.1.4.22.1",
+		"ipNetToMediaIfIndex":                 ".1.3.6.1.2.1.4.22.1.1",
+		"ipNetToMediaPhysAddress":             ".1.3.6.1.2.1.4.22.1.2",
+		"ipNetToMediaNetAddress":              ".1.3.6.1.2.1.4.22.1.3",
+		"ipNetToMediaType":                    ".1.3.6.1.2.1.4.22.1.4",
+		"ipRoutingDiscards":                   ".1.3.6.1.2.1.4.23",
+		"ipv6IpForwarding":                    ".1.3.6.1.2.1.4.25",
+		"ipv6IpDefaultHopLimit":               ".1.3.6.1.2.1.4.26",
+		"ipv4InterfaceTableLastChange":        ".1.3.6.1.2.1.4.27",
+		"ipv4InterfaceTable":                  ".1.3.6.1.2.1.4.28",
+		"ipv4InterfaceEntry":                  ".1.3.6.1.2.1.4.28.1",
+		"ipv4InterfaceIfIndex":                ".1.3.6.1.2.1.4.28.1.1",
+		"ipv4InterfaceReasmMaxSize":           ".1.3.6.1.2.1.4.28.1.2",
+		"ipv4InterfaceEnableStatus":           ".1.3.6.1.2.1.4.28.1.3",
+		"ipv4InterfaceRetransmitTime":         ".1.3.6.1.2.1.4.28.1.4",
+		"ipv6InterfaceTableLastChange":        ".1.3.6.1.2.1.4.29",
+		"ipv6InterfaceTable":                  ".1.3.6.1.2.1.4.30",
+		"ipv6InterfaceEntry":                  ".1.3.6.1.2.1.4.30.1",
+		"ipv6InterfaceIfIndex":                ".1.3.6.1.2.1.4.30.1.1",
+		"ipv6InterfaceReasmMaxSize":           ".1.3.6.1.2.1.4.30.1.2",
+		"ipv6InterfaceIdentifier":             ".1.3.6.1.2.1.4.30.1.3",
+		"ipv6InterfaceEnableStatus":           ".1.3.6.1.2.1.4.30.1.5",
+		"ipv6InterfaceReachableTime":          ".1.3.6.1.2.1.4.30.1.6",
+		"ipv6InterfaceRetransmitTime":         ".1.3.6.1.2.1.4.30.1.7",
+		"ipv6InterfaceForwarding":             ".1.3.6.1.2.1.4.30.1.8",
+		"ipAddressPrefixTable":                ".1.3.6.1.2.1.4.32",
+		"ipAddressPrefixEntry":                ".1.3.6.1.2.1.4.32.1",
+		"ipAddressPrefixIfIndex":              ".1.3.6.1.2.1.4.32.1.1",
+		"ipAddressPrefixType":                 ".1.3.6.1.2.1.4.32.1.2",
+		"ipAddressPrefixPrefix":               ".1.3.6.1.2.1.4.32.1.3",
+		"ipAddressPrefixLength":               ".1.3.6.1.2.1.4.32.1.4",
+		"ipAddressPrefixOrigin":               ".1.3.6.1.2.1.4.32.1.5",
+		"ipAddressPrefixOnLinkFlag":           ".1.3.6.1.2.1.4.32.1.6",
+		"ipAddressPrefixAutonomousFlag":       ".1.3.6.1.2.1.4.32.1.7",
+		"ipAddressPrefixAdvPreferredLifetime": ".1.3.6.1.2.1.4.32.1.8",
+		"ipAddressPrefixAdvValidLifetime":     ".1.3.6.1.2.1.4.32.1.9",
+		"ipAddressSpinLock":                   ".1.3.6.1.2.1.4.33",
+		"ipAddressTable":                      ".1.3.6.1.2.1.4.34",
+		"ipAddressEntry":                      ".1.3.6.1.2.1.4.34.1",
+		"ipAddressAddrType":                   ".1.3.6.1.2.1.4.34.1.1",
+		"ipAddressAddr":                       ".1.3.6.1.2.1.4.34.1.2",
+		"ipAddressIfIndex":                    ".1.3.6.1.2.1.4.34.1.3",
+		"ipAddressType":                       ".1.3.6.1.2.1.4.34.1.4",
+		"ipAddressPrefix":                     ".1.3.6.1.2.1.4.34.1.5",
+		"ipAddressOrigin":                     ".1.3.6.1.2.1.4.34.1.6",
+		"ipAddressStatus":                     ".1.3.6.1.2.1.4.34.1.7",
+		"ipAddressCreated":                    ".1.3.6.1.2.1.4.34.1.8",
+		"ipAddressLastChanged":                ".1.3.6.1.2.1.4.34.1.9",
+		"ipAddressRowStatus":                  ".1.3.6.1.2.1.4.34.1.10",
+		"ipAddressStorageType":                ".1.3.6.1.2.1.4.34.1.11",
+		"ipNetToPhysicalTable":                ".1.3.6.1.2.1.4.35",
+		"ipNetToPhysicalEntry":                ".1.3.6.1.2.1.4.35.1",
+		"ipNetToPhysicalIfIndex":              ".1.3.6.1.2.1.4.35.1.1",
+		"ipNetToPhysicalNetAddressType":       ".1.3.6.1.2.1.4.35.1.2",
+		"ipNetToPhysicalNetAddress":           ".1.3.6.1.2.1.4.35.1.3",
+		"ipNetToPhysicalPhysAddress":          ".1.3.6.1.2.1.4.35.1.4",
+		"ipNetToPhysicalLastUpdated":          ".1.3.6.1.2.1.4.35.1.5",
+		"ipNetToPhysicalType":                 ".1.3.6.1.2.1.4.35.1.6",
+		"ipNetToPhysicalState":                ".1.3.6.1.2.1.4.35.1.7",
+		"ipNetToPhysicalRowStatus":            ".1.3.6.1.2.1.4.35.1.8",
+		"icmp":                                ".1.3.6.1.2.1.5",
+		"icmpInMsgs":                          ".1.3.6.1.2.1.5.1",
+		"icmpInErrors":                        ".1.3.6.1.2.1.5.2",
+		"icmpInDestUnreachs":                  ".1.3.6.1.2.1.5.3",
+		"icmpInTimeExcds":                     ".1.3.6.1.2.1.5.4",
+		"icmpInParmProbs":                     ".1.3.6.1.2.1.5.5",
+		"icmpInSrcQuenchs":                    ".1.3.6.1.2.1.5.6",
+		"icmpInRedirects":                     ".1.3.6.1.2.1.5.7",
+		"icmpInEchos":                         ".1.3.6.1.2.1.5.8",
+		"icmpInEchoReps":                      ".1.3.6.1.2.1.5.9",
+		"icmpInTimestamps":                    ".1.3.6.1.2.1.5.10",
+		"icmpInTimestampReps":                 ".1.3.6.1.2.1.5.11",
+		"icmpInAddrMasks":                     ".1.3.6.1.2.1.5.12",
+		"icmpInAddrMaskReps":                  ".1.3.6.1.2.1.5.13",
+		"icmpOutMsgs":                         ".1.3.6.1.2.1.5.14",
+		"icmpOutErrors":                       ".1.3.6.1.2.1.5.15",
+		"icmpOutDestUnreachs":                 ".1.3.6.1.2.1.5.16",
+		"icmpOutTimeExcds":                    ".1.3.6.1.2.1.5.17",
+		"icmpOutParmProbs":                    ".1.3.6.1.2.1.5.18",
+		"icmpOutSrcQuenchs":                   ".1.3.6.1.2.1.5.19",
+		"icmpOutRedirects":                    ".1.3.6.1.2.1.5.20",
+		"icmpOutEchos":                        ".1.3.6.1.2.1.5.21",
+		"icmpOutEchoReps":                     ".1.3.6.1.2.1.5.22",
+		"icmpOutTimestamps":                   ".1.3.6.1.2.1.5.23",
+		"icmpOutTimestampReps":                ".1.3.6.1.2.1.5.24",
+		"icmpOutAddrMasks":                    ".1.3.6.1.2.1.5.25",
+		"icmpOutAddrMaskReps":                 ".1.3.6.1.2.1.5.26",
+	},
+	"BRIDGE-MIB": {
+		"dot1dBridge":                        ".1.3.6.1.2.1.17",
+		"dot1dBase":                          ".1.3.6.1.2.1.17.1",
+		"dot1dBaseBridgeAddress":             ".1.3.6.1.2.1.17.1.1",
+		"dot1dBaseNumPorts":                  ".1.3.6.1.2.1.17.1.2",
+		"dot1dBaseType":                      ".1.3.6.1.2.1.17.1.3",
+		"dot1dBasePortTable":                 ".1.3.6.1.2.1.17.1.4",
+		"dot1dBasePortEntry":                 ".1.3.6.1.2.1.17.1.4.1",
+		"dot1dBasePort":                      ".1.3.6.1.2.1.17.1.4.1.1",
+		"dot1dBasePortIfIndex":               ".1.3.6.1.2.1.17.1.4.1.2",
+		"dot1dBasePortCircuit":               ".1.3.6.1.2.1.17.1.4.1.3",
+		"dot1dBasePortDelayExceededDiscards": ".1.3.6.1.2.1.17.1.4.1.4",
+		"dot1dBasePortMtuExceededDiscards":   ".1.3.6.1.2.1.17.1.4.1.5",
+		"dot1dStp":                           ".1.3.6.1.2.1.17.2",
+		"dot1dStpProtocolSpecification":      ".1.3.6.1.2.1.17.2.1",
+		"dot1dStpPriority":                   ".1.3.6.1.2.1.17.2.2",
+		"dot1dStpTimeSinceTopologyChange":    ".1.3.6.1.2.1.17.2.3",
+		"dot1dStpTopChanges":                 ".1.3.6.1.2.1.17.2.4",
+		"dot1dStpDesignatedRoot":             ".1.3.6.1.2.1.17.2.5",
+		"dot1dStpRootCost":                   ".1.3.6.1.2.1.17.2.6",
+		"dot1dStpRootPort":                   ".1.3.6.1.2.1.17.2.7",
+		"dot1dStpMaxAge":                     ".1.3.6.1.2.1.17.2.8",
+		"dot1dStpHelloTime":                  ".1.3.6.1.2.1.17.2.9",
+		"dot1dStpHoldTime":                   ".1.3.6.1.2.1.17.2.10",
+		"dot1dStpForwardDelay":               ".1.3.6.1.2.1.17.2.11",
+		"dot1dStpBridgeMaxAge":               ".1.3.6.1.2.1.17.2.12",
+		"dot1dStpBridgeHelloTime":            ".1.3.6.1.2.1.17.2.13",
+		"dot1dStpBridgeForwardDelay":         ".1.3.6.1.2.1.17.2.14",
+		"dot1dStpPortTable":                  ".1.3.6.1.2.1.17.2.15",
+		"dot1dStpPortEntry":                  ".1.3.6.1.2.1.17.2.15.1",
+		"dot1dStpPort":                       ".1.3.6.1.2.1.17.2.15.1.1",
+		"dot1dStpPortPriority":               ".1.3.6.1.2.1.17.2.15.1.2",
+		"dot1dStpPortState":                  ".1.3.6.1.2.1.17.2.15.1.3",
+		"dot1dStpPortEnable":                 ".1.3.6.1.2.1.17.2.15.1.4",
+		"dot1dStpPortPathCost":               ".1.3.6.1.2.1.17.2.15.1.5",
+		"dot1dStpPortDesignatedRoot":         ".1.3.6.1.2.1.17.2.15.1.6",
+		"dot1dStpPortDesignatedCost":         ".1.3.6.1.2.1.17.2.15.1.7",
+		"dot1dStpPortDesignatedBridge":       ".1.3.6.1.2.1.17.2.15.1.8",
+		"dot1dStpPortDesignatedPort":         ".1.3.6.1.2.1.17.2.15.1.9",
+		"dot1dStpPortForwardTransitions":     ".1.3.6.1.2.1.17.2.15.1.10",
+		"dot1dStpPortPathCost32":             ".1.3.6.1.2.1.17.2.15.1.11",
+		"dot1dTp":                            ".1.3.6.1.2.1.17.4",
+		"dot1dTpLearnedEntryDiscards":        ".1.3.6.1.2.1.17.4.1",
+		"dot1dTpAgingTime":                   ".1.3.6.1.2.1.17.4.2",
+		"dot1dTpFdbTable":                    ".1.3.6.1.2.1.17.4.3",
+		"dot1dTpPortTable":                   ".1.3.6.1.2.1.17.4.4",
+		"dot1dTpFdbEntry":                    ".1.3.6.1.2.1.17.4.3.1",
+		"dot1dTpFdbAddress":                  ".1.3.6.1.2.1.17.4.3.1.1",
+		"dot1dTpFdbPort":                     ".1.3.6.1.2.1.17.4.3.1.2",
+		"dot1dTpFdbStatus":                   ".1.3.6.1.2.1.17.4.3.1.3",
+		"dot1dTpPortEntry":                   ".1.3.6.1.2.1.17.4.4.1",
+		"dot1dTpPort":                        ".1.3.6.1.2.1.17.4.4.1.1",
+		"dot1dTpPortMaxInfo":                 ".1.3.6.1.2.1.17.4.4.1.2",
+		"dot1dTpPortInFrames":                ".1.3.6.1.2.1.17.4.4.1.3",
+		"dot1dTpPortOutFrames":               ".1.3.6.1.2.1.17.4.4.1.4",
+		"dot1dTpPortInDiscards":              ".1.3.6.1.2.1.17.4.4.1.5",
+		"dot1dStatic":                        ".1.3.6.1.2.1.17.5",
+		"dot1dStaticTable":                   ".1.3.6.1.2.1.17.5.1",
+		"dot1dStaticEntry":                   ".1.3.6.1.2.1.17.5.1.1",
+		"dot1dStaticAddress":                 ".1.3.6.1.2.1.17.5.1.1.1",
+		"dot1dStaticReceivePort":             ".1.3.6.1.2.1.17.5.1.1.2",
+		"dot1dStaticAllowedToGoTo":           ".1.3.6.1.2.1.17.5.1.1.3",
+		"dot1dStaticStatus":                  ".1.3.6.1.2.1.17.5.1.1.4",
+		"newRoot":                            ".1.3.6.1.2.1.17.0.1",
+		"topologyChange":                     ".1.3.6.1.2.1.17.0.2",
+	},
+	"ENTITY-MIB": {
+		"entityMIB":                  ".1.3.6.1.2.1.47",
+		"entityMIBObjects":           ".1.3.6.1.2.1.47.1",
+		"entityPhysical":             ".1.3.6.1.2.1.47.1.1",
+		"entPhysicalTable":           ".1.3.6.1.2.1.47.1.1.1",
+		"entPhysicalEntry":           ".1.3.6.1.2.1.47.1.1.1.1",
+		"entPhysicalIndex":           ".1.3.6.1.2.1.47.1.1.1.1.1",
+		"entPhysicalDescr":           ".1.3.6.1.2.1.47.1.1.1.1.2",
+		"entPhysicalVendorType":      ".1.3.6.1.2.1.47.1.1.1.1.3",
+		"entPhysicalContainedIn":     ".1.3.6.1.2.1.47.1.1.1.1.4",
+		"entPhysicalClass":           ".1.3.6.1.2.1.47.1.1.1.1.5",
+		"entPhysicalParentRelPos":    ".1.3.6.1.2.1.47.1.1.1.1.6",
+		"entPhysicalName":            ".1.3.6.1.2.1.47.1.1.1.1.7",
+		"entPhysicalHardwareRev":     ".1.3.6.1.2.1.47.1.1.1.1.8",
+		"entPhysicalFirmwareRev":     ".1.3.6.1.2.1.47.1.1.1.1.9",
+		"entPhysicalSoftwareRev":     ".1.3.6.1.2.1.47.1.1.1.1.10",
+		"entPhysicalSerialNum":       ".1.3.6.1.2.1.47.1.1.1.1.11",
+		"entPhysicalMfgName":         ".1.3.6.1.2.1.47.1.1.1.1.12",
+		"entPhysicalModelName":       ".1.3.6.1.2.1.47.1.1.1.1.13",
+		"entPhysicalAlias":           ".1.3.6.1.2.1.47.1.1.1.1.14",
+		"entPhysicalAssetID":         ".1.3.6.1.2.1.47.1.1.1.1.15",
+		"entPhysicalIsFRU":           ".1.3.6.1.2.1.47.1.1.1.1.16",
+		"entPhysicalMfgDate":         ".1.3.6.1.2.1.47.1.1.1.1.17",
+		"entPhysicalUris":            ".1.3.6.1.2.1.47.1.1.1.1.18",
+		"entityLogical":              ".1.3.6.1.2.1.47.1.2",
+		"entLogicalTable":            ".1.3.6.1.2.1.47.1.2.1",
+		"entLogicalEntry":            ".1.3.6.1.2.1.47.1.2.1.1",
+		"entLogicalIndex":            ".1.3.6.1.2.1.47.1.2.1.1.1",
+		"entLogicalDescr":            ".1.3.6.1.2.1.47.1.2.1.1.2",
+		"entLogicalType":             ".1.3.6.1.2.1.47.1.2.1.1.3",
+		"entLogicalCommunity":        ".1.3.6.1.2.1.47.1.2.1.1.4",
+		"entLogicalTAddress":         ".1.3.6.1.2.1.47.1.2.1.1.5",
+		"entLogicalTDomain":          ".1.3.6.1.2.1.47.1.2.1.1.6",
+		"entLogicalContextEngineID":  ".1.3.6.1.2.1.47.1.2.1.1.7",
+		"entLogicalContextName":      ".1.3.6.1.2.1.47.1.2.1.1.8",
+		"entityMapping":              ".1.3.6.1.2.1.47.1.3",
+		"entLPMappingTable":          ".1.3.6.1.2.1.47.1.3.1",
+		"entLPMappingEntry":          ".1.3.6.1.2.1.47.1.3.1.1",
+		"entLPPhysicalIndex":         ".1.3.6.1.2.1.47.1.3.1.1.1",
+		"entAliasMappingTable":       ".1.3.6.1.2.1.47.1.3.2",
+		"entAliasMappingEntry":       ".1.3.6.1.2.1.47.1.3.2.1",
+		"entAliasLogicalIndexOrZero": ".1.3.6.1.2.1.47.1.3.2.1.1",
+		"entAliasMappingIdentifier":  ".1.3.6.1.2.1.47.1.3.2.1.2",
+		"entPhysicalContainsTable":   ".1.3.6.1.2.1.47.1.3.3",
+		"entPhysicalContainsEntry":   ".1.3.6.1.2.1.47.1.3.3.1",
+		"entPhysicalChildIndex":      ".1.3.6.1.2.1.47.1.3.3.1.1",
+		"entityGeneral":              ".1.3.6.1.2.1.47.1.4",
+		"entLastChangeTime":          ".1.3.6.1.2.1.47.1.4.1",
+		"entConfigChange":            ".1.3.6.1.2.1.47.2.0.1",
+	},
+	"HOST-RESOURCES-MIB": {
+		"host":                          ".1.3.6.1.2.1.25",
+		"hrSystem":                      ".1.3.6.1.2.1.25.1",
+		"hrSystemUptime":                ".1.3.6.1.2.1.25.1.1",
+		"hrSystemDate":                  ".1.3.6.1.2.1.25.1.2",
+		"hrSystemInitialLoadDevice":     ".1.3.6.1.2.1.25.1.3",
+		"hrSystemInitialLoadParameters": ".1.3.6.1.2.1.25.1.4",
+		"hrSystemNumUsers":              ".1.3.6.1.2.1.25.1.5",
+		"hrSystemProcesses":             ".1.3.6.1.2.1.25.1.6",
+		"hrSystemMaxProcesses":          ".1.3.6.1.2.1.25.1.7",
+		"hrStorage":                     ".1.3.6.1.2.1.25.2",
+		"hrStorageTypes":                ".1.3.6.1.2.1.25.2.1",
+		"hrMemorySize":                  ".1.3.6.1.2.1.25.2.2",
+		"hrStorageTable":                ".1.3.6.1.2.1.25.2.3",
+		"hrStorageEntry":                ".1.3.6.1.2.1.25.2.3.1",
+		"hrStorageIndex":                ".1.3.6.1.2.1.25.2.3.1.1",
+		"hrStorageType":                 ".1.3.6.1.2.1.25.2.3.1.2",
+		"hrStorageDescr":                ".1.3.6.1.2.1.25.2.3.1.3",
+		"hrStorageAllocationUnits":      ".1.3.6.1.2.1.25.2.3.1.4",
+		"hrStorageSize":                 ".1.3.6.1.2.1.25.2.3.1.5",
+		"hrStorageUsed":                 ".1.3.6.1.2.1.25.2.3.1.6",
+		"hrStorageAllocationFailures":   ".1.3.6.1.2.1.25.2.3.1.7",
+		"hrDevice":                      ".1.3.6.1.2.1.25.3",
+		"hrDeviceTypes":                 ".1.3.6.1.2.1.25.3.1",
+		"hrDeviceTable":                 ".1.3.6.1.2.1.25.3.2",
+		"hrDeviceEntry":                 ".1.3.6.1.2.1.25.3.2.1",
+		"hrDeviceIndex":                 ".1.3.6.1.2.1.25.3.2.1.1",
+		"hrDeviceType":                  ".1.3.6.1.2.1.25.3.2.1.2",
+		"hrDeviceDescr":                 ".1.3.6.1.2.1.25.3.2.1.3",
+		"hrDeviceID":                    ".1.3.6.1.2.1.25.3.2.1.4",
+		"hrDeviceStatus":                ".1.3.6.1.2.1.25.3.2.1.5",
+		"hrDeviceErrors":                ".1.3.6.1.2.1.25.3.2.1.6",
+		"hrProcessorTable":              ".1.3.6.1.2.1.25.3.3",
+		"hrProcessorEntry":              ".1.3.6.1.2.1.25.3.3.1",
+		"hrProcessorFrwID":              ".1.3.6.1.2.1.25.3.3.1.1",
+		"hrProcessorLoad":               ".1.3.6.1.2.1.25.3.3.1.2",
+		"hrNetworkTable":                ".1.3.6.1.2.1.25.3.4",
+		"hrNetworkEntry":                ".1.3.6.1.2.1.25.3.4.1",
+		"hrNetworkIfIndex":              ".1.3.6.1.2.1.25.3.4.1.1",
+		"hrDiskStorageTable":            ".1.3.6.1.2.1.25.3.6",
+		"hrDiskStorageEntry":            ".1.3.6.1.2.1.25.3.6.1",
+		"hrDiskStorageAccess":           ".1.3.6.1.2.1.25.3.6.1.1",
+		"hrDiskStorageMedia":            ".1.3.6.1.2.1.25.3.6.1.2",
+		"hrDiskStorageRemoveble":        ".1.3.6.1.2.1.25.3.6.1.3",
+		"hrDiskStorageCapacity":         ".1.3.6.1.2.1.25.3.6.1.4",
+		"hrPartitionTable":              ".1.3.6.1.2.1.25.3.7",
+		"hrPartitionEntry":              ".1.3.6.1.2.1.25.3.7.1",
+		"hrPartitionIndex":              ".1.3.6.1.2.1.25.3.7.1.1",
+		"hrPartitionLabel":              ".1.3.6.1.2.1.25.3.7.1.2",
+		"hrPartitionID":                 ".1.3.6.1.2.1.25.3.7.1.3",
+		"hrPartitionSize":               ".1.3.6.1.2.1.25.3.7.1.4",
+		"hrPartitionFSIndex":            ".1.3.6.1.2.1.25.3.7.1.5",
+		"hrFSTable":                     ".1.3.6.1.2.1.25.3.8",
+		"hrFSEntry":                     ".1.3.6.1.2.1.25.3.8.1",
+		"hrFSIndex":                     ".1.3.6.1.2.1.25.3.8.1.1",
+		"hrFSMountPoint":                ".1.3.6.1.2.1.25.3.8.1.2",
+		"hrFSRemoteMountPoint":          ".1.3.6.1.2.1.25.3.8.1.3",
+		"hrFSType":                      ".1.3.6.1.2.1.25.3.8.1.4",
+		"hrFSAccess":                    ".1.3.6.1.2.1.25.3.8.1.5",
+		"hrFSBootable":                  ".1.3.6.1.2.1.25.3.8.1.6",
+		"hrFSStorageIndex":              ".1.3.6.1.2.1.25.3.8.1.7",
+		"hrFSLastFullBackupDate":        ".1.3.6.1.2.1.25.3.8.1.8",
+		"hrFSLastPartialBackupDate":     ".1.3.6.1.2.1.25.3.8.1.9",
+		"hrSWRun":                       ".1.3.6.1.2.1.25.4",
+		"hrSWOSIndex":                   ".1.3.6.1.2.1.25.4.1",
+		"hrSWRunTable":                  ".1.3.6.1.2.1.25.4.2",
+		"hrSWRunEntry":                  ".1.3.6.1.2.1.25.4.2.1",
+		"hrSWRunIndex":                  ".1.3.6.1.2.1.25.4.2.1.1",
+		"hrSWRunName":                   ".1.3.6.1.2.1.25.4.2.1.2",
+		"hrSWRunID":                     ".1.3.6.1.2.1.25.4.2.1.3",
+		"hrSWRunPath":                   ".1.3.6.1.2.1.25.4.2.1.4",
+		"hrSWRunParameters":             ".1.3.6.1.2.1.25.4.2.1.5",
+		"hrSWRunType":                   ".1.3.6.1.2.1.25.4.2.1.6",
+		"hrSWRunStatus":                 ".1.3.6.1.2.1.25.4.2.1.7",
+		"hrSWRunPerf":                   ".1.3.6.1.2.1.25.5",
+		"hrSWRunPerfTable":              ".1.3.6.1.2.1.25.5.1",
+		"hrSWRunPerfEntry":              ".1.3.6.1.2.1.25.5.1.1",
+		"hrSWRunPerfCPU":                ".1.3.6.1.2.1.25.5.1.1.1",
+		"hrSWRunPerfMem":                ".1.3.6.1.2.1.25.5.1.1.2",
+		"hrSWInstalled":                 ".1.3.6.1.2.1.25.6",
+		"hrSWInstalledLastChange":       ".1.3.6.1.2.1.25.6.1",
+		"hrSWInstalledLastUpdateTime":   ".1.3.6.1.2.1.25.6.2",
+		"hrSWInstalledTable":            ".1.3.6.1.2.1.25.6.3",
+		"hrSWInstalledEntry":            ".1.3.6.1.2.1.25.6.3.1",
+		"hrSWInstalledIndex":            ".1.3.6.1.2.1.25.6.3.1.1",
+		"hrSWInstalledName":             ".1.3.6.1.2.1.25.6.3.1.2",
+		"hrSWInstalledID":               ".1.3.6.1.2.1.25.6.3.1.3",
+		"hrSWInstalledType":             ".1.3.6.1.2.1.25.6.3.1.4",
+		"hrSWInstalledDate":             ".1.3.6.1.2.1.25.6.3.1.5",
+	},
+}
@@ -0,0 +1,157 @@
+package gosnmpHelper
+
+import (
+	"context"
+	"errors"
+	"github.com/jjcinaz/gosnmpHelper/mib"
+	"github.com/jjcinaz/gosnmpHelper/snmptest"
+	"reflect"
+	"testing"
+)
+
+type namedIfRow struct {
+	Index  string `oidcol:"index"`
+	Descr  string `oidcol:"ifDescr"`
+	Speed  uint   `oidcol:"ifSpeed"`
+	Octets uint   `oidcol:"IF-MIB::ifInOctets"`
+}
+
+type namedInfo struct {
+	SysDesc   string                `oid:"SNMPv2-MIB::sysDescr.0"`
+	SysName   string                `oid:"SNMPv2-MIB::sysName.0"`
+	IfNumber  int                   `oid:"IF-MIB::ifNumber.0"`
+	IfStatus  map[string]int        `oidx:"IF-MIB::ifOperStatus.(\\d+)"`
+	IfTable   map[string]namedIfRow `oidtable:"IF-MIB::ifEntry"`
+	HCOctets  map[string]uint64     `oidx:"IF-MIB::ifHCInOctets\\.(\\d+)" walk:"IF-MIB::ifXEntry"`
+	Addresses map[string]string     `oidx:"^IP-MIB::ipAdEntAddr.(.+)$"`
+}
+
+type unknownNameInfo struct {
+	SysDesc string `oid:"SNMPv2-MIB::sysDescr.0"`
+	Nested  struct {
+		Private int `oid:"EXAMPLE-MIB::exampleValue.0"`
+	}
+	Table map[string]namedIfRow `oidtable:"IF-MIB::ifXEntry"`
+}
+
+func TestResolveName(t *testing.T) {
+	tests := []struct {
+		name    string
+		want    string
+		wantErr error
+	}{
+		{name: "SNMPv2-MIB::sysDescr.0", want: ".1.3.6.1.2.1.1.1.0"},
+		{name: "IF-MIB::ifEntry", want: ".1.3.6.1.2.1.2.2.1"},
+		{name: "IF-MIB::ifHCInOctets.6", want: ".1.3.6.1.2.1.31.1.1.1.6.6"},
+		{name: "IP-MIB::ipNetToMediaPhysAddress.3.10.0.0.1", want: ".1.3.6.1.2.1.4.22.1.2.3.10.0.0.1"},
+		{name: "BRIDGE-MIB::dot1dTpFdbPort", want: ".1.3.6.1.2.1.17.4.3.1.2"},
+		{name: "ENTITY-MIB::entPhysicalSerialNum.1", want: ".1.3.6.1.2.1.47.1.1.1.1.11.1"},
+		{name: "HOST-RESOURCES-MIB::hrStorageUsed", want: ".1.3.6.1.2.1.25.2.3.1.6"},
+		{name: "1.3.6.1.2.1.1.5.0", want: ".1.3.6.1.2.1.1.5.0"},
+		{name: "IF-MIB::sysDescr.0", wantErr: ErrUnknownName},
+		{name: "NO-SUCH-MIB::sysDescr.0", wantErr: ErrUnknownName},
+		{name: "IF-MIB::ifDescr.x", wantErr: ErrInvalidOID},
+		{name: "ifDescr.0", wantErr: ErrInvalidOID},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ResolveName(tt.name)
+			if !errors.Is(err, tt.wantErr) || (tt.wantErr == nil && err != nil) {
+				t.Fatalf("ResolveName() err = %v, want %v", err, tt.wantErr)
+			}
+			if err == nil && got.String() != tt.want {
+				t.Errorf("ResolveName() = %s, want %s", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestRegisterNames(t *testing.T) {
+	if err := RegisterNames("EXAMPLE-MIB", map[string]string{"exampleValue": "1.3.6.1.4.1.99999.x"}); !errors.Is(err, ErrInvalidOID) {
+		t.Errorf("RegisterNames() err = %v, want ErrInvalidOID", err)
+	}
+	if _, err := NewCodec(reflect.TypeOf(unknownNameInfo{})); !errors.Is(err, ErrUnknownName) {
+		t.Fatalf("NewCodec() err = %v, want ErrUnknownName", err)
+	}
+	err := RegisterNames("EXAMPLE-MIB", map[string]string{"exampleValue": "1.3.6.1.4.1.99999.1"})
+	if err != nil {
+		t.Fatalf("RegisterNames() err = %v", err)
+	}
+	// Still fails as the oidcol names are not in ifXEntry, but the registered name now resolves
+	_, err = NewCodec(reflect.TypeOf(unknownNameInfo{}))
+	if !errors.Is(err, ErrUnknownName) {
+		t.Fatalf("NewCodec() err = %v, want ErrUnknownName", err)
+	}
+	if oid, err := ResolveName("EXAMPLE-MIB::exampleValue.0"); err != nil || oid.String() != ".1.3.6.1.4.1.99999.1.0" {
+		t.Errorf("ResolveName() = %v, %v", oid, err)
+	}
+}
+
+func TestNamedTags(t *testing.T) {
+	agent, err := snmptest.NewAgentFromWalkFile("testdata/router.walk")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer agent.Close()
+	client := agent.Client()
+
+	var info namedInfo
+	if got, want := GetOidsFromStructTags(&info, false), []string{".1.3.6.1.2.1.1.1.0", ".1.3.6.1.2.1.1.5.0", ".1.3.6.1.2.1.2.1.0"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("GetOidsFromStructTags() = %v, want %v", got, want)
+	}
+	c, _ := Compile[namedInfo]()
+	roots, err := c.WalkRoots()
+	want := []string{".1.3.6.1.2.1.2.2.1", ".1.3.6.1.2.1.4.20.1.1", ".1.3.6.1.2.1.31.1.1.1"}
+	if err != nil || !reflect.DeepEqual(roots, want) {
+		t.Errorf("WalkRoots() = %v, %v, want %v", roots, err, want)
+	}
+	if err = Fetch(context.Background(), client, &info); err != nil {
+		t.Fatalf("Fetch() err = %v", err)
+	}
+	if err = WalkInto(context.Background(), client, &info); err != nil {
+		t.Fatalf("WalkInto() err = %v", err)
+	}
+	wantInfo := namedInfo{
+		SysDesc:  "Linux router 5.10.0-21-amd64 #1 SMP x86_64",
+		SysName:  "router",
+		IfNumber: 3,
+		IfStatus: map[string]int{"1": 1, "2": 1, "6": 2},
+		IfTable: map[string]namedIfRow{
+			"1": {Index: "1", Descr: "lo", Speed: 10000000, Octets: 123456},
+			"2": {Index: "2", Descr: "eth0", Speed: 1000000000, Octets: 4000000000},
+			"6": {Index: "6", Descr: "eth1", Speed: 1000000000, Octets: 0},
+		},
+		HCOctets:  map[string]uint64{"2": 98765432109876},
+		Addresses: map[string]string{"10.0.0.1": "10.0.0.1", "127.0.0.1": "127.0.0.1"},
+	}
+	if !reflect.DeepEqual(info, wantInfo) {
+		t.Errorf("got %+v\nwant %+v", info, wantInfo)
+	}
+
+	pdus := MarshalStructToPDUs(namedInfo{SysName: "core1"})
+	if len(pdus) != 3 || pdus[1].Name != ".1.3.6.1.2.1.1.5.0" || pdus[1].Value != "core1" {
+		t.Errorf("MarshalStructToPDUs() = %v", pdus)
+	}
+}
+
+func TestRegisterTree(t *testing.T) {
+	tree := mib.NewTree("mib/testdata")
+	if err := tree.Load("ACME-MIB"); err != nil {
+		t.Fatalf("Load() err = %v", err)
+	}
+	if err := RegisterTree(tree); err != nil {
+		t.Fatalf("RegisterTree() err = %v", err)
+	}
+	var acme struct {
+		Name  string `oid:"ACME-MIB::acmeName.0"`
+		State int    `oid:"ACME-MIB::acmeState.0"`
+	}
+	want := []string{".1.3.6.1.4.1.99999.1.1.0", ".1.3.6.1.4.1.99999.1.2.0"}
+	if got := GetOidsFromStructTags(&acme, false); !reflect.DeepEqual(got, want) {
+		t.Errorf("GetOidsFromStructTags() = %v, want %v", got, want)
+	}
+	// The built-in names are still there
+	if oid, err := ResolveName("IF-MIB::ifHCOutOctets"); err != nil || oid.String() != ".1.3.6.1.2.1.31.1.1.1.10" {
+		t.Errorf("ResolveName() = %v, %v", oid, err)
+	}
+}
@@ -2,6 +2,8 @@ package gosnmpHelper
 
 import (
 	"errors"
+	"fmt"
+	"github.com/jjcinaz/gosnmpHelper/internal/oids"
 	"strconv"
 	"strings"
 )
@@ -25,3 +27,131 @@ func GetOIDIndexAsInt(oid string) (int, error) {
 	}
 	return 0, errors.New("invalid OID")
 }
+
+// OID is an SNMP object identifier held as its sub-identifiers
+type OID []uint32
+
+// Parse an OID in dotted form such as ".1.3.6.1.2.1.1.1.0".  The leading dot is optional, so "1.3.6" and
+// ".1.3.6" parse to the same OID.
+func ParseOID(s string) (OID, error) {
+	oid, err := oids.Parse(s)
+	if err != nil {
+		return nil, fmt.Errorf("%w %q", ErrInvalidOID, strings.TrimSpace(s))
+	}
+	return oid, nil
+}
+
+// Same as ParseOID() but panics if the OID is invalid.  Intended for OIDs known at compile time.
+func MustParseOID(s string) OID {
+	oid, err := ParseOID(s)
+	if err != nil {
+		panic(err)
+	}
+	return oid
+}
+
+// Return the OID in dotted form with a leading dot, as used by gosnmp.  For example ".1.3.6.1"
+func (o OID) String() string {
+	return oids.Format(o)
+}
+
+// Return the OID in dotted form without a leading dot.  For example "1.3.6.1"
+func (o OID) StringNoDot() string {
+	return oids.Format(o)[1:]
+}
+
+// Compare two OIDs in SNMP lexicographic order, as used by GetNext and walks.  The result will be 0 if
+// o == other, -1 if o < other, and +1 if o > other.  An OID sorts before any OID it is a prefix of.
+func (o OID) Compare(other OID) int {
+	return oids.Compare(o, other)
+}
+
+// Report whether two OIDs are the same
+func (o OID) Equal(other OID) bool {
+	return o.Compare(other) == 0
+}
+
+// Report whether the OID begins with prefix, i.e. whether it is within the subtree rooted at prefix
+func (o OID) HasPrefix(prefix OID) bool {
+	return len(o) >= len(prefix) && o[:len(prefix)].Equal(prefix)
+}
+
+// Return the OID without the leading prefix.  If the OID doesn't begin with prefix, it is returned unchanged.
+// For example, trimming the ifDescr column ".1.3.6.1.2.1.2.2.1.2" from ".1.3.6.1.2.1.2.2.1.2.6" leaves the
+// instance index "6".
+func (o OID) TrimPrefix(prefix OID) OID {
+	if !o.HasPrefix(prefix) {
+		return o
+	}
+	return o.SubIdentifiers(len(prefix), len(o))
+}
+
+// Return the OID with the last sub-identifier removed.  The parent of an empty OID is empty.
+func (o OID) Parent() OID {
+	if len(o) == 0 {
+		return OID{}
+	}
+	return o.SubIdentifiers(0, len(o)-1)
+}
+
+// Return a new OID with the sub-identifiers added to the end.  The original OID is not modified.
+func (o OID) Append(subs ...uint32) OID {
+	result := make(OID, 0, len(o)+len(subs))
+	return append(append(result, o...), subs...)
+}
+
+// Return a copy of the sub-identifiers from index from up to, but not including, index to.  The indexes
+// are clamped to the length of the OID.
+func (o OID) SubIdentifiers(from, to int) OID {
+	if to > len(o) {
+		to = len(o)
+	}
+	if from < 0 {
+		from = 0
+	}
+	if from >= to {
+		return OID{}
+	}
+	result := make(OID, to-from)
+	copy(result, o[from:to])
+	return result
+}
+
+// canonicalOID returns an OID string in the form used when matching struct tags, with a leading dot.
+// Strings which are not valid OIDs are returned unchanged.
+func canonicalOID(s string) string {
+	if isCanonicalOID(s) {
+		return s
+	}
+	if oid, err := ParseOID(s); err == nil {
+		return oid.String()
+	}
+	return s
+}
+
+// isCanonicalOID reports whether s is already in the form canonicalOID() returns, as PDU names from gosnmp
+// almost always are, so that it needn't be parsed and formatted again.  Sub-identifiers of 10 digits, which
+// may be out of range, are left to ParseOID().
+func isCanonicalOID(s string) bool {
+	if len(s) < 2 || s[0] != '.' {
+		return false
+	}
+	digits := 0
+	for i := 1; i < len(s); i++ {
+		switch c := s[i]; {
+		case c == '.':
+			if digits == 0 {
+				return false
+			}
+			digits = 0
+		case c >= '0' && c <= '9':
+			if digits == 1 && s[i-1] == '0' || digits == 9 {
+				return false
+			}
+			digits++
+		default:
+			return false
+		}
+	}
+	return digits > 0
+}
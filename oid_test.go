@@ -0,0 +1,168 @@
+package gosnmpHelper
+
+import (
+	"errors"
+	snmp "github.com/gosnmp/gosnmp"
+	"reflect"
+	"sort"
+	"testing"
+)
+
+func TestParseOID(t *testing.T) {
+	tests := []struct {
+		name    string
+		s       string
+		want    OID
+		wantErr bool
+	}{
+		{name: "Leading dot", s: ".1.3.6.1.2.1.1.1.0", want: OID{1, 3, 6, 1, 2, 1, 1, 1, 0}},
+		{name: "No leading dot", s: "1.3.6.1.2.1.1.1.0", want: OID{1, 3, 6, 1, 2, 1, 1, 1, 0}},
+		{name: "Max sub-identifier", s: "1.3.4294967295", want: OID{1, 3, 4294967295}},
+		{name: "Empty", s: "", wantErr: true},
+		{name: "Dot", s: ".", wantErr: true},
+		{name: "Empty part", s: "1..3", wantErr: true},
+		{name: "Trailing dot", s: "1.3.", wantErr: true},
+		{name: "Too big", s: "1.3.4294967296", wantErr: true},
+		{name: "Not numeric", s: "1.3.six", wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseOID(tt.s)
+			if (err != nil) != tt.wantErr || (err != nil && !errors.Is(err, ErrInvalidOID)) {
+				t.Fatalf("ParseOID() err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseOID() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestOIDOperations(t *testing.T) {
+	ifDescr := MustParseOID(".1.3.6.1.2.1.2.2.1.2")
+	inst := MustParseOID("1.3.6.1.2.1.2.2.1.2.6")
+
+	if got := inst.String(); got != ".1.3.6.1.2.1.2.2.1.2.6" {
+		t.Errorf("String() = %q", got)
+	}
+	if got := inst.StringNoDot(); got != "1.3.6.1.2.1.2.2.1.2.6" {
+		t.Errorf("StringNoDot() = %q", got)
+	}
+	if !inst.HasPrefix(ifDescr) || ifDescr.HasPrefix(inst) || !inst.HasPrefix(OID{}) {
+		t.Errorf("HasPrefix() failed")
+	}
+	if got := inst.TrimPrefix(ifDescr); !got.Equal(OID{6}) {
+		t.Errorf("TrimPrefix() = %v", got)
+	}
+	if got := ifDescr.TrimPrefix(inst); !got.Equal(ifDescr) {
+		t.Errorf("TrimPrefix() of non-prefix = %v", got)
+	}
+	if got := inst.Parent(); !got.Equal(ifDescr) {
+		t.Errorf("Parent() = %v", got)
+	}
+	if got := (OID{}).Parent(); len(got) != 0 {
+		t.Errorf("Parent() of empty = %v", got)
+	}
+	if got := ifDescr.Append(6); !got.Equal(inst) {
+		t.Errorf("Append() = %v", got)
+	}
+	// Append must not write into the backing array of the original
+	base := make(OID, 2, 10)
+	base[0], base[1] = 1, 3
+	a, b := base.Append(6), base.Append(7)
+	if a[2] != 6 || b[2] != 7 {
+		t.Errorf("Append() aliased: %v %v", a, b)
+	}
+	if got := inst.SubIdentifiers(9, 11); !got.Equal(OID{2, 6}) {
+		t.Errorf("SubIdentifiers() = %v", got)
+	}
+	if got := inst.SubIdentifiers(-5, 100); !got.Equal(inst) {
+		t.Errorf("SubIdentifiers() clamped = %v", got)
+	}
+	if got := inst.SubIdentifiers(5, 2); len(got) != 0 {
+		t.Errorf("SubIdentifiers() reversed = %v", got)
+	}
+}
+
+func TestOIDCompare(t *testing.T) {
+	oids := []OID{
+		MustParseOID(".1.3.6.1.2.1.2.2.1.10.1"),
+		MustParseOID(".1.3.6.1.2.1.2.2.1.2.10"),
+		MustParseOID(".1.3.6.1.2.1.2.2.1.2"),
+		MustParseOID(".1.3.6.1.2.1.2.2.1.2.9"),
+		MustParseOID(".1.3.6.1.2.1.1"),
+	}
+	sort.Slice(oids, func(i, j int) bool { return oids[i].Compare(oids[j]) < 0 })
+	want := []string{
+		".1.3.6.1.2.1.1",
+		".1.3.6.1.2.1.2.2.1.2",
+		".1.3.6.1.2.1.2.2.1.2.9",
+		".1.3.6.1.2.1.2.2.1.2.10",
+		".1.3.6.1.2.1.2.2.1.10.1",
+	}
+	for i := range oids {
+		if oids[i].String() != want[i] {
+			t.Errorf("sorted[%d] = %v, want %v", i, oids[i], want[i])
+		}
+	}
+	if MustParseOID("1.3.6").Compare(MustParseOID(".1.3.6")) != 0 {
+		t.Errorf("Compare() of equal OIDs != 0")
+	}
+}
+
+func TestMarshalPDUToStructNoDot(t *testing.T) {
+	var info struct {
+		SysDesc string `oid:"1.3.6.1.2.1.1.1.0"`
+		SysName string `oid:".1.3.6.1.2.1.1.5.0"`
+		Intfs   SysIntfs
+		Arp     map[string]ArpRow `oidtable:"1.3.6.1.2.1.4.22.1"`
+	}
+	pdus := []snmp.SnmpPDU{
+		{Name: ".1.3.6.1.2.1.1.1.0", Type: snmp.OctetString, Value: []byte("desc")},
+		{Name: "1.3.6.1.2.1.1.5.0", Type: snmp.OctetString, Value: []byte("name")},
+		{Name: "1.3.6.1.2.1.2.2.1.2.6", Type: snmp.OctetString, Value: []byte("eth0")},
+		{Name: ".1.3.6.1.2.1.4.22.1.4.3.10.0.0.1", Type: snmp.Integer, Value: 3},
+	}
+	MarshalPDUsToStruct(pdus, &info)
+	if info.SysDesc != "desc" || info.SysName != "name" {
+		t.Errorf("SysDesc = %q, SysName = %q", info.SysDesc, info.SysName)
+	}
+	if info.Intfs.IfDesc["6"] != "eth0" {
+		t.Errorf("IfDesc = %v", info.Intfs.IfDesc)
+	}
+	if info.Arp["3.10.0.0.1"].Type != 3 {
+		t.Errorf("Arp = %v", info.Arp)
+	}
+}
+
+func TestCanonicalOID(t *testing.T) {
+	tests := []struct {
+		in, want  string
+		canonical bool
+	}{
+		{".1.3.6.1.2.1.1.1.0", ".1.3.6.1.2.1.1.1.0", true},
+		{".0", ".0", true},
+		{".1.3.6.1.4.1.9.999999999", ".1.3.6.1.4.1.9.999999999", true},
+		{"1.3.6.1", ".1.3.6.1", false},
+		{".1.03.6", ".1.3.6", false},
+		{".1.3.4294967295", ".1.3.4294967295", false},
+		{".1.3.4294967296", ".1.3.4294967296", false},
+		{".1..3", ".1..3", false},
+		{".1.3.", ".1.3.", false},
+		{".", ".", false},
+		{"", "", false},
+		{" .1.3", ".1.3", false},
+		{"IF-MIB::ifDescr", "IF-MIB::ifDescr", false},
+	}
+	for _, tt := range tests {
+		if got := isCanonicalOID(tt.in); got != tt.canonical {
+			t.Errorf("isCanonicalOID(%q) = %v, want %v", tt.in, got, tt.canonical)
+		}
+		if got := canonicalOID(tt.in); got != tt.want {
+			t.Errorf("canonicalOID(%q) = %q, want %q", tt.in, got, tt.want)
+		}
+	}
+	if allocs := testing.AllocsPerRun(100, func() { canonicalOID(".1.3.6.1.2.1.2.2.1.2.10") }); allocs != 0 {
+		t.Errorf("canonicalOID() of a canonical OID made %v allocations", allocs)
+	}
+}
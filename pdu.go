@@ -1,8 +1,13 @@
 package gosnmpHelper
 
 import (
+	"errors"
+	"fmt"
 	"github.com/gosnmp/gosnmp"
+	"math"
+	"net"
 	"strconv"
+	"time"
 )
 
 // Get PDU value as a uint32 value.  PDU value should be a numeric type, else 0 will be returned.
@@ -383,7 +388,8 @@ func GetAsFloat64(pdu gosnmp.SnmpPDU) float64 {
 }
 
 // Get PDU value as a string.  An empty string will be returned for nil PDU values.
-// Numeric values are converted to string format in base-10.
+// Numeric values are converted to string format in base-10.  OctetStrings are returned as their raw
+// octets; use FormatDisplayHint() to format them as their textual convention directs.
 func GetAsString(pdu gosnmp.SnmpPDU) string {
 	if pdu.Value != nil {
 		switch v := pdu.Value.(type) {
@@ -435,3 +441,288 @@ func GetAsBytes(pdu gosnmp.SnmpPDU) []byte {
 	}
 	return []byte{}
 }
+
+// Get PDU value as a uint32 value, reporting any problem with the conversion.  The returned value is the same
+// as GetAsUint32() would return.  The error is ErrNilValue if the PDU value is nil, ErrNotNumeric if it
+// isn't a number or OctetString, ErrParse if an OctetString doesn't hold a number, ErrSignLoss if the value is
+// negative, or ErrOverflow if the value doesn't fit in 32 bits.
+func GetAsUint32E(pdu gosnmp.SnmpPDU) (uint32, error) {
+	return GetAsUint32(pdu), checkInteger(pdu, 32, false)
+}
+
+// Get PDU value as a uint64 value, reporting any problem with the conversion.
+// See GetAsUint32E() for details on the errors.
+func GetAsUint64E(pdu gosnmp.SnmpPDU) (uint64, error) {
+	return GetAsUint64(pdu), checkInteger(pdu, 64, false)
+}
+
+// Get PDU value as a uint value, reporting any problem with the conversion.
+// See GetAsUint32E() for details on the errors.
+func GetAsUintE(pdu gosnmp.SnmpPDU) (uint, error) {
+	return GetAsUint(pdu), checkInteger(pdu, strconv.IntSize, false)
+}
+
+// Get PDU value as an int32 value, reporting any problem with the conversion.  The returned value is the same
+// as GetAsInt32() would return.  The error is ErrNilValue if the PDU value is nil, ErrNotNumeric if it
+// isn't a number or OctetString, ErrParse if an OctetString doesn't hold a number, or ErrOverflow if the
+// value doesn't fit in a signed 32-bit integer.
+func GetAsInt32E(pdu gosnmp.SnmpPDU) (int32, error) {
+	return GetAsInt32(pdu), checkInteger(pdu, 32, true)
+}
+
+// Get PDU value as an int64 value, reporting any problem with the conversion.
+// See GetAsInt32E() for details on the errors.
+func GetAsInt64E(pdu gosnmp.SnmpPDU) (int64, error) {
+	return GetAsInt64(pdu), checkInteger(pdu, 64, true)
+}
+
+// Get PDU value as an int value, reporting any problem with the conversion.
+// See GetAsInt32E() for details on the errors.
+func GetAsIntE(pdu gosnmp.SnmpPDU) (int, error) {
+	return GetAsInt(pdu), checkInteger(pdu, strconv.IntSize, true)
+}
+
+// Get PDU value as a float32 value, reporting any problem with the conversion.  The returned value is the
+// same as GetAsFloat32() would return.  The error is ErrNilValue if the PDU value is nil, ErrNotNumeric if it
+// isn't a number or OctetString, ErrParse if an OctetString doesn't hold a number, or ErrOverflow if the
+// value is beyond the range of a float32.
+func GetAsFloat32E(pdu gosnmp.SnmpPDU) (float32, error) {
+	return GetAsFloat32(pdu), checkFloat(pdu, 32)
+}
+
+// Get PDU value as a float64 value, reporting any problem with the conversion.
+// See GetAsFloat32E() for details on the errors.
+func GetAsFloat64E(pdu gosnmp.SnmpPDU) (float64, error) {
+	return GetAsFloat64(pdu), checkFloat(pdu, 64)
+}
+
+// octetString returns the PDU value as a string if the PDU is an OctetString holding a string or []byte
+func octetString(pdu gosnmp.SnmpPDU) (string, bool) {
+	if pdu.Type != gosnmp.OctetString {
+		return "", false
+	}
+	switch v := pdu.Value.(type) {
+	case string:
+		return v, true
+	case []byte:
+		return string(v), true
+	}
+	return "", false
+}
+
+// parseError maps a strconv error onto ErrOverflow or ErrParse
+func parseError(err error) error {
+	if errors.Is(err, strconv.ErrRange) {
+		return ErrOverflow
+	}
+	return ErrParse
+}
+
+// checkInteger reports whether the PDU value fits in an integer of the given size and signedness
+func checkInteger(pdu gosnmp.SnmpPDU, bits int, signed bool) error {
+	var (
+		i int64
+		u uint64
+	)
+	switch v := pdu.Value.(type) {
+	case nil:
+		return ErrNilValue
+	case uint8:
+		u = uint64(v)
+	case uint16:
+		u = uint64(v)
+	case uint32:
+		u = uint64(v)
+	case uint64:
+		u = v
+	case uint:
+		u = uint64(v)
+	case int8:
+		i = int64(v)
+	case int16:
+		i = int64(v)
+	case int32:
+		i = int64(v)
+	case int64:
+		i = v
+	case int:
+		i = int64(v)
+	default:
+		s, ok := octetString(pdu)
+		if !ok {
+			return ErrNotNumeric
+		}
+		if signed {
+			if _, err := strconv.ParseInt(s, 10, bits); err != nil {
+				return parseError(err)
+			}
+			return nil
+		}
+		if _, err := strconv.ParseUint(s, 10, bits); err != nil {
+			if n, err2 := strconv.ParseInt(s, 10, 64); err2 == nil && n < 0 {
+				return ErrSignLoss
+			}
+			return parseError(err)
+		}
+		return nil
+	}
+	if i < 0 {
+		if !signed {
+			return ErrSignLoss
+		}
+		if bits < 64 && i < -1<<(bits-1) {
+			return ErrOverflow
+		}
+		return nil
+	}
+	if i > 0 {
+		u = uint64(i)
+	}
+	max := uint64(math.MaxUint64)
+	if bits < 64 {
+		max = 1<<bits - 1
+	}
+	if signed {
+		max >>= 1
+	}
+	if u > max {
+		return ErrOverflow
+	}
+	return nil
+}
+
+// checkFloat reports whether the PDU value can be represented as a float of the given size
+func checkFloat(pdu gosnmp.SnmpPDU, bits int) error {
+	var f float64
+	switch v := pdu.Value.(type) {
+	case nil:
+		return ErrNilValue
+	case uint8, uint16, uint32, uint64, uint, int8, int16, int32, int64, int:
+		return nil
+	case float32:
+		return nil
+	case float64:
+		f = v
+	default:
+		s, ok := octetString(pdu)
+		if !ok {
+			return ErrNotNumeric
+		}
+		var err error
+		if f, err = strconv.ParseFloat(s, 64); err != nil {
+			return parseError(err)
+		}
+	}
+	if bits == 32 && math.Abs(f) > math.MaxFloat32 && !math.IsInf(f, 0) {
+		return ErrOverflow
+	}
+	return nil
+}
+
+// Get PDU value as an IP address.  IPAddress PDUs as well as InetAddress style OctetStrings holding 4 or 16
+// bytes (or 8 or 20 bytes where a zone index follows the address) are decoded, as are OctetStrings holding
+// the address in text form.  A value which parses as a textual address, such as "10.0.0.1", is taken as text
+// even when its length matches a raw address.  IPv4 addresses are returned in their 4-byte form.
+// If the PDU value is nil or not an address, nil will be returned.
+func GetAsIP(pdu gosnmp.SnmpPDU) net.IP {
+	var b []byte
+	switch v := pdu.Value.(type) {
+	case string:
+		b = []byte(v)
+	case []byte:
+		b = v
+	default:
+		return nil
+	}
+	if ip := net.ParseIP(string(b)); ip != nil {
+		if ip4 := ip.To4(); ip4 != nil {
+			return ip4
+		}
+		return ip
+	}
+	switch len(b) {
+	case net.IPv4len, net.IPv4len + 4:
+		return net.IP{b[0], b[1], b[2], b[3]}
+	case net.IPv6len, net.IPv6len + 4:
+		ip := make(net.IP, net.IPv6len)
+		copy(ip, b)
+		return ip
+	}
+	return nil
+}
+
+// Get PDU value as a time.Duration.  The value is taken to be in hundredths of a second as is the case for
+// TimeTicks PDUs such as sysUpTime.  If PDU value is nil or not numeric, 0 will be returned.
+func GetAsDuration(pdu gosnmp.SnmpPDU) time.Duration {
+	switch pdu.Value.(type) {
+	case uint8, uint16, uint32, uint64, uint, int8, int16, int32, int64, int:
+		return time.Duration(GetAsInt64(pdu)) * 10 * time.Millisecond
+	}
+	return 0
+}
+
+// Get PDU value as a time.Time from an RFC 2579 DateAndTime OctetString.  The 11 byte form carries the
+// offset from UTC which is used as the location of the returned time.  The 8 byte form has no offset and
+// UTC is assumed.  If PDU value is nil or not a valid DateAndTime, the zero time will be returned.
+func GetAsDateAndTime(pdu gosnmp.SnmpPDU) time.Time {
+	t, _ := parseDateAndTime(pdu.Value)
+	return t
+}
+
+// parseDateAndTime decodes an RFC 2579 DateAndTime held in a []byte or string
+func parseDateAndTime(value interface{}) (time.Time, error) {
+	var b []byte
+	switch v := value.(type) {
+	case nil:
+		return time.Time{}, ErrNilValue
+	case []byte:
+		b = v
+	case string:
+		b = []byte(v)
+	default:
+		return time.Time{}, ErrParse
+	}
+	if len(b) != 8 && len(b) != 11 {
+		return time.Time{}, ErrParse
+	}
+	year := int(b[0])<<8 | int(b[1])
+	month, day, hour, minute, sec, decisec := b[2], b[3], b[4], b[5], b[6], b[7]
+	if month < 1 || month > 12 || day < 1 || day > 31 || hour > 23 || minute > 59 || sec > 60 || decisec > 9 {
+		return time.Time{}, ErrParse
+	}
+	loc := time.UTC
+	if len(b) == 11 {
+		if (b[8] != '+' && b[8] != '-') || b[9] > 14 || b[10] > 59 {
+			return time.Time{}, ErrParse
+		}
+		offset := int(b[9])*3600 + int(b[10])*60
+		if b[8] == '-' {
+			offset = -offset
+		}
+		loc = time.FixedZone("", offset)
+	}
+	return time.Date(year, time.Month(month), int(day), int(hour), int(minute), int(sec),
+		int(decisec)*100000000, loc), nil
+}
+
+// Get PDU value as a MAC address.  The value may be the raw 6 bytes of the address, as is the case for
+// ifPhysAddress or dot1dTpFdbAddress, or text in any of the formats accepted by NormalizeMac().
+// An error is returned if the PDU value is nil or not a MAC address.
+func GetAsMAC(pdu gosnmp.SnmpPDU) (net.HardwareAddr, error) {
+	var s string
+	switch v := pdu.Value.(type) {
+	case nil:
+		return nil, ErrNilValue
+	case []byte:
+		s = string(v)
+	case string:
+		s = v
+	default:
+		return nil, ErrParse
+	}
+	mac, err := parseMac(s)
+	if err != nil {
+		return nil, fmt.Errorf("%w: %w", ErrParse, err)
+	}
+	return mac, nil
+}
@@ -0,0 +1,194 @@
+package gosnmpHelper
+
+import (
+	"errors"
+	snmp "github.com/gosnmp/gosnmp"
+	"net"
+	"net/netip"
+	"testing"
+	"time"
+)
+
+func TestGetAsIntegerE(t *testing.T) {
+	tests := []struct {
+		name    string
+		pdu     snmp.SnmpPDU
+		get     func(snmp.SnmpPDU) (interface{}, error)
+		want    interface{}
+		wantErr error
+	}{
+		{name: "Uint32", pdu: snmp.SnmpPDU{Type: snmp.Gauge32, Value: uint(1000)},
+			get: wrapE(GetAsUint32E), want: uint32(1000)},
+		{name: "Uint32 zero", pdu: snmp.SnmpPDU{Type: snmp.Integer, Value: 0},
+			get: wrapE(GetAsUint32E), want: uint32(0)},
+		{name: "Uint32 nil", pdu: snmp.SnmpPDU{Type: snmp.Null},
+			get: wrapE(GetAsUint32E), want: uint32(0), wantErr: ErrNilValue},
+		{name: "Uint32 overflow", pdu: snmp.SnmpPDU{Type: snmp.Counter64, Value: uint64(1) << 32},
+			get: wrapE(GetAsUint32E), want: uint32(0), wantErr: ErrOverflow},
+		{name: "Uint32 negative", pdu: snmp.SnmpPDU{Type: snmp.Integer, Value: -1},
+			get: wrapE(GetAsUint32E), want: uint32(0xffffffff), wantErr: ErrSignLoss},
+		{name: "Uint32 string", pdu: snmp.SnmpPDU{Type: snmp.OctetString, Value: []byte("42")},
+			get: wrapE(GetAsUint32E), want: uint32(42)},
+		{name: "Uint32 string negative", pdu: snmp.SnmpPDU{Type: snmp.OctetString, Value: "-42"},
+			get: wrapE(GetAsUint32E), want: uint32(0), wantErr: ErrSignLoss},
+		{name: "Uint32 string overflow", pdu: snmp.SnmpPDU{Type: snmp.OctetString, Value: "4294967296"},
+			get: wrapE(GetAsUint32E), want: uint32(0xffffffff), wantErr: ErrOverflow},
+		{name: "Uint32 unparseable", pdu: snmp.SnmpPDU{Type: snmp.OctetString, Value: []byte("n/a")},
+			get: wrapE(GetAsUint32E), want: uint32(0), wantErr: ErrParse},
+		{name: "Uint32 not numeric", pdu: snmp.SnmpPDU{Type: snmp.IPAddress, Value: "10.0.0.1"},
+			get: wrapE(GetAsUint32E), want: uint32(0), wantErr: ErrNotNumeric},
+		{name: "Uint64", pdu: snmp.SnmpPDU{Type: snmp.Counter64, Value: uint64(1) << 40},
+			get: wrapE(GetAsUint64E), want: uint64(1) << 40},
+		{name: "Uint64 negative", pdu: snmp.SnmpPDU{Type: snmp.Integer, Value: int64(-5)},
+			get: wrapE(GetAsUint64E), want: uint64(0xfffffffffffffffb), wantErr: ErrSignLoss},
+		{name: "Int32", pdu: snmp.SnmpPDU{Type: snmp.Integer, Value: -7},
+			get: wrapE(GetAsInt32E), want: int32(-7)},
+		{name: "Int32 overflow", pdu: snmp.SnmpPDU{Type: snmp.Gauge32, Value: uint32(0x80000000)},
+			get: wrapE(GetAsInt32E), want: int32(-0x80000000), wantErr: ErrOverflow},
+		{name: "Int32 underflow", pdu: snmp.SnmpPDU{Type: snmp.Integer, Value: int64(-0x80000001)},
+			get: wrapE(GetAsInt32E), want: int32(0x7fffffff), wantErr: ErrOverflow},
+		{name: "Int64 overflow", pdu: snmp.SnmpPDU{Type: snmp.Counter64, Value: uint64(1) << 63},
+			get: wrapE(GetAsInt64E), want: int64(-1 << 63), wantErr: ErrOverflow},
+		{name: "Int string", pdu: snmp.SnmpPDU{Type: snmp.OctetString, Value: "-12"},
+			get: wrapE(GetAsIntE), want: -12},
+		{name: "Uint", pdu: snmp.SnmpPDU{Type: snmp.TimeTicks, Value: uint32(12)},
+			get: wrapE(GetAsUintE), want: uint(12)},
+		{name: "Float32", pdu: snmp.SnmpPDU{Type: snmp.OpaqueFloat, Value: float32(1.5)},
+			get: wrapE(GetAsFloat32E), want: float32(1.5)},
+		{name: "Float32 overflow", pdu: snmp.SnmpPDU{Type: snmp.OctetString, Value: "1e300"},
+			get: wrapE(GetAsFloat32E), wantErr: ErrOverflow},
+		{name: "Float64 string", pdu: snmp.SnmpPDU{Type: snmp.OctetString, Value: []byte("0.25")},
+			get: wrapE(GetAsFloat64E), want: 0.25},
+		{name: "Float64 unparseable", pdu: snmp.SnmpPDU{Type: snmp.OctetString, Value: []byte("x")},
+			get: wrapE(GetAsFloat64E), want: float64(0), wantErr: ErrParse},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := tt.get(tt.pdu)
+			if !errors.Is(err, tt.wantErr) || (err == nil) != (tt.wantErr == nil) {
+				t.Errorf("err = %v, want %v", err, tt.wantErr)
+			}
+			if tt.want != nil && got != tt.want {
+				t.Errorf("got %v (%T), want %v (%T)", got, got, tt.want, tt.want)
+			}
+		})
+	}
+}
+
+// wrapE adapts a checked getter for use in a table of tests
+func wrapE[T any](get func(snmp.SnmpPDU) (T, error)) func(snmp.SnmpPDU) (interface{}, error) {
+	return func(pdu snmp.SnmpPDU) (interface{}, error) {
+		v, err := get(pdu)
+		return v, err
+	}
+}
+
+func TestGetAsIP(t *testing.T) {
+	tests := []struct {
+		name string
+		pdu  snmp.SnmpPDU
+		want net.IP
+	}{
+		{name: "IPAddress", pdu: snmp.SnmpPDU{Type: snmp.IPAddress, Value: "10.1.2.3"}, want: net.IP{10, 1, 2, 3}},
+		{name: "InetAddress v4", pdu: snmp.SnmpPDU{Type: snmp.OctetString, Value: []byte{192, 168, 0, 1}}, want: net.IP{192, 168, 0, 1}},
+		{name: "InetAddress v4z", pdu: snmp.SnmpPDU{Type: snmp.OctetString, Value: []byte{192, 168, 0, 1, 0, 0, 0, 3}}, want: net.IP{192, 168, 0, 1}},
+		{name: "InetAddress v6", pdu: snmp.SnmpPDU{Type: snmp.OctetString, Value: []byte(net.ParseIP("2001:db8::1"))}, want: net.ParseIP("2001:db8::1")},
+		{name: "Text", pdu: snmp.SnmpPDU{Type: snmp.OctetString, Value: []byte("2001:db8::2")}, want: net.ParseIP("2001:db8::2")},
+		{name: "Text v4 8 bytes", pdu: snmp.SnmpPDU{Type: snmp.OctetString, Value: []byte("10.0.0.1")}, want: net.IP{10, 0, 0, 1}},
+		{name: "Text v6 16 bytes", pdu: snmp.SnmpPDU{Type: snmp.OctetString, Value: []byte("2001:db8::dead:1")}, want: net.ParseIP("2001:db8::dead:1")},
+		{name: "Text v4 string", pdu: snmp.SnmpPDU{Type: snmp.OctetString, Value: "10.0.0.1"}, want: net.IP{10, 0, 0, 1}},
+		{name: "Nil", pdu: snmp.SnmpPDU{Type: snmp.Null}},
+		{name: "Garbage", pdu: snmp.SnmpPDU{Type: snmp.OctetString, Value: []byte("eth0/1")}},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			if got := GetAsIP(tt.pdu); !got.Equal(tt.want) || (got == nil) != (tt.want == nil) {
+				t.Errorf("GetAsIP() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestTextIPMembers(t *testing.T) {
+	var info struct {
+		IP   net.IP     `oid:".1.3.6.1.4.1.9999.1.0"`
+		Addr netip.Addr `oid:".1.3.6.1.4.1.9999.2.0"`
+	}
+	pdus := []snmp.SnmpPDU{
+		{Name: ".1.3.6.1.4.1.9999.1.0", Type: snmp.OctetString, Value: []byte("10.0.0.1")},
+		{Name: ".1.3.6.1.4.1.9999.2.0", Type: snmp.OctetString, Value: []byte("2001:db8::dead:1")},
+	}
+	if err := MarshalPDUsToStructE(pdus, &info); err != nil {
+		t.Fatalf("MarshalPDUsToStructE() err = %v", err)
+	}
+	if !info.IP.Equal(net.IP{10, 0, 0, 1}) || info.Addr != netip.MustParseAddr("2001:db8::dead:1") {
+		t.Errorf("MarshalPDUsToStructE() = %+v", info)
+	}
+}
+
+func TestGetAsDuration(t *testing.T) {
+	pdu := snmp.SnmpPDU{Type: snmp.TimeTicks, Value: uint32(12345)}
+	if got, want := GetAsDuration(pdu), 123450*time.Millisecond; got != want {
+		t.Errorf("GetAsDuration() = %v, want %v", got, want)
+	}
+	if got := GetAsDuration(snmp.SnmpPDU{Type: snmp.Null}); got != 0 {
+		t.Errorf("GetAsDuration() = %v, want 0", got)
+	}
+}
+
+func TestGetAsDateAndTime(t *testing.T) {
+	tests := []struct {
+		name  string
+		value interface{}
+		want  time.Time
+	}{
+		{name: "11 bytes", value: []byte{0x07, 0xe9, 10, 16, 13, 30, 15, 5, '-', 7, 0},
+			want: time.Date(2025, 10, 16, 13, 30, 15, 500000000, time.FixedZone("", -7*3600))},
+		{name: "8 bytes", value: []byte{0x07, 0xe9, 1, 2, 3, 4, 5, 0},
+			want: time.Date(2025, 1, 2, 3, 4, 5, 0, time.UTC)},
+		{name: "Zeroed", value: []byte{0, 0, 0, 0, 0, 0, 0, 0}},
+		{name: "Bad direction", value: []byte{0x07, 0xe9, 1, 2, 3, 4, 5, 0, 'x', 0, 0}},
+		{name: "Short", value: []byte{0x07, 0xe9, 1}},
+		{name: "Nil"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got := GetAsDateAndTime(snmp.SnmpPDU{Type: snmp.OctetString, Value: tt.value})
+			if !got.Equal(tt.want) {
+				t.Errorf("GetAsDateAndTime() = %v, want %v", got, tt.want)
+			}
+			_, gotOffset := got.Zone()
+			if _, wantOffset := tt.want.Zone(); gotOffset != wantOffset {
+				t.Errorf("GetAsDateAndTime() zone offset = %d, want %d", gotOffset, wantOffset)
+			}
+		})
+	}
+}
+
+func TestGetAsMAC(t *testing.T) {
+	want := net.HardwareAddr{0x00, 0x11, 0x22, 0xaa, 0xbb, 0xcc}
+	tests := []struct {
+		name    string
+		value   interface{}
+		want    net.HardwareAddr
+		wantErr error
+	}{
+		{name: "Raw", value: []byte{0x00, 0x11, 0x22, 0xaa, 0xbb, 0xcc}, want: want},
+		{name: "Colons", value: []byte("00:11:22:AA:BB:CC"), want: want},
+		{name: "Dashes", value: "0-11-22-aa-bb-cc", want: want},
+		{name: "Bare", value: "001122aabbcc", want: want},
+		{name: "Short", value: []byte{0x00, 0x11, 0x22}, wantErr: ErrParse},
+		{name: "Nil", wantErr: ErrNilValue},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := GetAsMAC(snmp.SnmpPDU{Type: snmp.OctetString, Value: tt.value})
+			if !errors.Is(err, tt.wantErr) || (err == nil) != (tt.wantErr == nil) {
+				t.Errorf("GetAsMAC() err = %v, want %v", err, tt.wantErr)
+			}
+			if got.String() != tt.want.String() {
+				t.Errorf("GetAsMAC() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
@@ -0,0 +1,203 @@
+package gosnmpHelper
+
+import (
+	"context"
+	"github.com/gosnmp/gosnmp"
+	"math/rand"
+	"net"
+	"strconv"
+	"sync"
+	"time"
+)
+
+// PollResult is the outcome of one poll of a target by a Poller
+type PollResult[T any] struct {
+	Target   string // the client's Target, with the port when it isn't 161
+	Client   *gosnmp.GoSNMP
+	Value    *T // filled in as far as the poll got, even when Err is set
+	Err      error
+	Start    time.Time
+	Duration time.Duration
+}
+
+/*
+A Poller polls a set of targets at a regular interval, filling a new T for each poll with Fetch() and
+WalkInto(), so T may have oid, oidx and oidtable tags.  For example:
+
+	poller := NewPoller[SysInfo](clients, time.Minute)
+	poller.Concurrency = 20
+	poller.Jitter = 5 * time.Second
+	results, err := poller.Start(ctx)
+	if err != nil {
+		...
+	}
+	for result := range results {
+		if result.Err != nil {
+			log.Printf("%s: %v", result.Target, result.Err)
+		}
+		...
+	}
+
+Each client gives the target and its settings, such as the community and version.  Clients which aren't
+connected are connected for the first poll and closed when the Poller stops.  A client must not be used
+elsewhere while the Poller is running.
+
+The fields may be changed before the Poller is started but not while it's running.
+*/
+type Poller[T any] struct {
+	Clients  []*gosnmp.GoSNMP
+	Interval time.Duration
+	// Jitter is the most by which each poll is randomly delayed, to spread the load on the network and the
+	// agents.  Zero polls every target on the interval exactly.
+	Jitter time.Duration
+	// Concurrency is the most targets polled at once.  Zero is no limit.
+	Concurrency int
+	// Timeout is the longest a poll may take, which defaults to Interval
+	Timeout time.Duration
+}
+
+// NewPoller returns a Poller for the clients with the given interval between polls
+func NewPoller[T any](clients []*gosnmp.GoSNMP, interval time.Duration) *Poller[T] {
+	return &Poller[T]{Clients: clients, Interval: interval}
+}
+
+/*
+Run polls the targets until ctx is done, calling handle with each result.  Calls to handle for different
+targets may be concurrent, while those for any one target are in order.  A target isn't polled again until
+handle returns for its previous poll, and polls which would start while the previous one is still running
+are skipped.  Run returns once all polls have finished, with an error only if T is not a struct type that can
+be polled (see NewCodec()) or Interval isn't positive.
+*/
+func (p *Poller[T]) Run(ctx context.Context, handle func(PollResult[T])) error {
+	if _, err := Compile[T](); err != nil {
+		return err
+	}
+	if p.Interval <= 0 {
+		return ErrInvalidInterval
+	}
+	var sem chan struct{}
+	if p.Concurrency > 0 {
+		sem = make(chan struct{}, p.Concurrency)
+	}
+	var wg sync.WaitGroup
+	for _, client := range p.Clients {
+		wg.Add(1)
+		go func(client *gosnmp.GoSNMP) {
+			defer wg.Done()
+			p.pollTarget(ctx, client, sem, handle)
+		}(client)
+	}
+	wg.Wait()
+	return nil
+}
+
+/*
+Start polls the targets in the background until ctx is done, delivering the results on the returned
+channel, which is closed once all polls have finished.  The results must be received promptly, as a
+target isn't polled again until its previous result has been received.  See Run() for details.
+*/
+func (p *Poller[T]) Start(ctx context.Context) (<-chan PollResult[T], error) {
+	if _, err := Compile[T](); err != nil {
+		return nil, err
+	}
+	if p.Interval <= 0 {
+		return nil, ErrInvalidInterval
+	}
+	results := make(chan PollResult[T], len(p.Clients))
+	go func() {
+		defer close(results)
+		_ = p.Run(ctx, func(result PollResult[T]) {
+			select {
+			case results <- result:
+			case <-ctx.Done():
+			}
+		})
+	}()
+	return results, nil
+}
+
+// pollTarget polls one client on the Poller's schedule until ctx is done
+func (p *Poller[T]) pollTarget(ctx context.Context, client *gosnmp.GoSNMP, sem chan struct{}, handle func(PollResult[T])) {
+	connected := false
+	defer func() {
+		if connected && client.Conn != nil {
+			client.Conn.Close()
+		}
+	}()
+	next := time.Now()
+	for {
+		delay := time.Until(next)
+		if p.Jitter > 0 {
+			delay += time.Duration(rand.Int63n(int64(p.Jitter)))
+		}
+		timer := time.NewTimer(delay)
+		select {
+		case <-ctx.Done():
+			timer.Stop()
+			return
+		case <-timer.C:
+		}
+		if sem != nil {
+			select {
+			case <-ctx.Done():
+				return
+			case sem <- struct{}{}:
+			}
+		}
+		if client.Conn == nil {
+			if err := client.Connect(); err != nil {
+				p.release(sem)
+				handle(PollResult[T]{Target: targetName(client), Client: client, Value: new(T), Err: err, Start: time.Now()})
+				next = p.nextPoll(next)
+				continue
+			}
+			connected = true
+		}
+		result := p.poll(ctx, client)
+		p.release(sem)
+		if ctx.Err() != nil && result.Err != nil {
+			// Cancelled part way through, which isn't a result worth reporting
+			return
+		}
+		handle(result)
+		next = p.nextPoll(next)
+	}
+}
+
+// poll fills a new T from the client
+func (p *Poller[T]) poll(ctx context.Context, client *gosnmp.GoSNMP) PollResult[T] {
+	timeout := p.Timeout
+	if timeout <= 0 {
+		timeout = p.Interval
+	}
+	ctx, cancel := context.WithTimeout(ctx, timeout)
+	defer cancel()
+	result := PollResult[T]{Target: targetName(client), Client: client, Value: new(T), Start: time.Now()}
+	result.Err = fetchAndWalk(ctx, client, result.Value)
+	result.Duration = time.Since(result.Start)
+	return result
+}
+
+// nextPoll returns the time of the next poll after the one scheduled at last, skipping any already missed
+func (p *Poller[T]) nextPoll(last time.Time) time.Time {
+	next := last.Add(p.Interval)
+	if now := time.Now(); next.Before(now) {
+		next = next.Add(now.Sub(next).Truncate(p.Interval) + p.Interval)
+	}
+	return next
+}
+
+// release frees a slot taken from the concurrency limit
+func (p *Poller[T]) release(sem chan struct{}) {
+	if sem != nil {
+		<-sem
+	}
+}
+
+// targetName describes a client's target as host, or host:port when the port isn't the default
+func targetName(client *gosnmp.GoSNMP) string {
+	if client.Port == 0 || client.Port == 161 {
+		return client.Target
+	}
+	return net.JoinHostPort(client.Target, strconv.Itoa(int(client.Port)))
+}
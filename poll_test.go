@@ -0,0 +1,101 @@
+package gosnmpHelper
+
+import (
+	"context"
+	"errors"
+	snmp "github.com/gosnmp/gosnmp"
+	"github.com/jjcinaz/gosnmpHelper/snmptest"
+	"sync"
+	"testing"
+	"time"
+)
+
+type pollInfo struct {
+	SysName string            `oid:".1.3.6.1.2.1.1.5.0"`
+	IfDescr map[string]string `oidx:"IF-MIB::ifDescr.(\\d+)"`
+}
+
+func TestPoller(t *testing.T) {
+	router, err := snmptest.NewAgentFromWalkFile("testdata/router.walk")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer router.Close()
+	other := snmptest.NewAgentFromMap(map[string]interface{}{".1.3.6.1.2.1.1.5.0": "switch"})
+	defer other.Close()
+	bad := &snmp.GoSNMP{Target: "256.256.256.256", Port: 161, Community: "public", Version: snmp.Version2c}
+	clients := []*snmp.GoSNMP{router.Client(), other.Client(), bad}
+
+	poller := NewPoller[pollInfo](clients, 20*time.Millisecond)
+	poller.Concurrency = 1
+	poller.Jitter = 5 * time.Millisecond
+	ctx, cancel := context.WithTimeout(context.Background(), 5*time.Second)
+	defer cancel()
+	results, err := poller.Start(ctx)
+	if err != nil {
+		t.Fatalf("Start() err = %v", err)
+	}
+	counts := map[string]int{}
+	for result := range results {
+		switch result.Client {
+		case clients[0]:
+			if result.Err != nil || result.Value.SysName != "router" || result.Value.IfDescr["2"] != "eth0" {
+				t.Errorf("router result = %+v", result)
+			}
+			if result.Duration <= 0 || result.Start.IsZero() {
+				t.Errorf("router result has no timing: %+v", result)
+			}
+		case clients[1]:
+			if result.Err != nil || result.Value.SysName != "switch" || len(result.Value.IfDescr) != 0 {
+				t.Errorf("switch result = %+v", result)
+			}
+			if result.Target != targetName(clients[1]) || targetName(clients[1]) == clients[1].Target {
+				t.Errorf("switch result target = %s", result.Target)
+			}
+		case bad:
+			if result.Err == nil || result.Value == nil || result.Value.SysName != "" {
+				t.Errorf("bad target result = %+v", result)
+			}
+		}
+		if counts[result.Target]++; counts[targetName(clients[0])] >= 3 && counts[targetName(clients[1])] >= 3 && counts["256.256.256.256"] >= 3 {
+			cancel()
+		}
+	}
+	if ctx.Err() == nil || len(counts) != 3 {
+		t.Errorf("results ended early: %v", counts)
+	}
+	if bad.Conn != nil {
+		t.Errorf("bad target was left connected")
+	}
+}
+
+func TestPollerRun(t *testing.T) {
+	agent := snmptest.NewAgentFromMap(map[string]interface{}{".1.3.6.1.2.1.1.5.0": "router"})
+	defer agent.Close()
+	poller := NewPoller[pollInfo]([]*snmp.GoSNMP{agent.Client()}, time.Hour)
+	ctx, cancel := context.WithCancel(context.Background())
+	var mu sync.Mutex
+	var got []PollResult[pollInfo]
+	done := make(chan error)
+	go func() {
+		done <- poller.Run(ctx, func(result PollResult[pollInfo]) {
+			mu.Lock()
+			got = append(got, result)
+			mu.Unlock()
+			cancel()
+		})
+	}()
+	if err := <-done; err != nil {
+		t.Fatalf("Run() err = %v", err)
+	}
+	if len(got) != 1 || got[0].Value.SysName != "router" {
+		t.Errorf("Run() results = %+v", got)
+	}
+
+	if _, err := NewPoller[int](nil, time.Second).Start(context.Background()); !errors.Is(err, ErrNotStruct) {
+		t.Errorf("Start() err = %v, want ErrNotStruct", err)
+	}
+	if err := NewPoller[pollInfo](nil, 0).Run(context.Background(), nil); !errors.Is(err, ErrInvalidInterval) {
+		t.Errorf("Run() err = %v, want ErrInvalidInterval", err)
+	}
+}
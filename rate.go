@@ -0,0 +1,151 @@
+package gosnmpHelper
+
+import (
+	"errors"
+	"fmt"
+	"github.com/gosnmp/gosnmp"
+	"math"
+	"sync"
+	"time"
+)
+
+// The OID of sysUpTime.0, which Rates() looks for amongst the PDUs
+const sysUpTimeOID = ".1.3.6.1.2.1.1.3.0"
+
+/*
+A RateTracker turns successive samples of Counter32 and Counter64 values into per-second rates.  Samples
+are keyed by target and OID, so one tracker can serve every device being polled.  The interval between
+samples is taken from the agent's sysUpTime rather than the local clock, so delays in the network or in
+polling don't distort the rates.
+
+A Counter32 which is lower than its previous sample is taken to have wrapped once.  A Counter64 is never
+expected to wrap, so a lower value is a discontinuity, as is a change of type or a sysUpTime lower than the
+previous one for the target, as when the agent restarts.  On a discontinuity no rate is given and the
+samples start afresh.  sysUpTime itself wraps after 497 days, which costs one interval.
+
+	tracker := NewRateTracker()
+	...
+	result, err := client.Get([]string{".1.3.6.1.2.1.1.3.0", ".1.3.6.1.2.1.31.1.1.1.6.2"})
+	...
+	rates, err := tracker.Rates(client.Target, result.Variables)
+	fmt.Printf("eth0 in: %.0f bytes/s\n", rates[".1.3.6.1.2.1.31.1.1.1.6.2"])
+
+A RateTracker is safe for concurrent use.
+*/
+type RateTracker struct {
+	mu      sync.Mutex
+	samples map[rateKey]rateSample
+	uptimes map[string]time.Duration // the latest sysUpTime of each target
+}
+
+type rateKey struct {
+	target string
+	oid    string
+}
+
+type rateSample struct {
+	typ    gosnmp.Asn1BER
+	value  uint64
+	uptime time.Duration
+}
+
+// NewRateTracker returns an empty RateTracker
+func NewRateTracker() *RateTracker {
+	return &RateTracker{samples: map[rateKey]rateSample{}, uptimes: map[string]time.Duration{}}
+}
+
+/*
+Update records a sample of a Counter32 or Counter64 PDU from target, taken when the agent's sysUpTime was
+uptime, and returns the rate per second since the previous sample.  The returned bool is false when there
+is no rate: for the first sample of the OID, after a discontinuity, or when sysUpTime hasn't moved on since
+the previous sample.  An error wrapping ErrNotCounter is returned for PDUs of other types, such as
+NoSuchInstance.
+*/
+func (rt *RateTracker) Update(target string, uptime time.Duration, pdu gosnmp.SnmpPDU) (float64, bool, error) {
+	if pdu.Type != gosnmp.Counter32 && pdu.Type != gosnmp.Counter64 {
+		return 0, false, fmt.Errorf("%s: %w (%v)", pdu.Name, ErrNotCounter, pdu.Type)
+	}
+	value, err := GetAsUint64E(pdu)
+	if err != nil {
+		return 0, false, fmt.Errorf("%s: %w", pdu.Name, err)
+	}
+	rt.mu.Lock()
+	defer rt.mu.Unlock()
+	rt.setUptime(target, uptime)
+	key := rateKey{target: target, oid: canonicalOID(pdu.Name)}
+	prev, found := rt.samples[key]
+	if found && uptime == prev.uptime && value == prev.value && pdu.Type == prev.typ {
+		// The same sample again, such as from an agent caching its values
+		return 0, false, nil
+	}
+	rt.samples[key] = rateSample{typ: pdu.Type, value: value, uptime: uptime}
+	if !found || uptime <= prev.uptime || pdu.Type != prev.typ {
+		return 0, false, nil
+	}
+	delta := value - prev.value
+	if value < prev.value {
+		if pdu.Type == gosnmp.Counter64 {
+			return 0, false, nil
+		}
+		delta = value + (math.MaxUint32 + 1) - prev.value
+	}
+	return float64(delta) / (uptime - prev.uptime).Seconds(), true, nil
+}
+
+/*
+Rates records every Counter32 and Counter64 PDU in pdus, which must include sysUpTime.0, and returns the
+per-second rates keyed by OID (with a leading dot).  Counters without a rate yet, such as on the first call,
+are left out.  PDUs of other types are ignored, apart from NoSuchObject, NoSuchInstance and EndOfMibView
+which are reported in the returned error along with a missing sysUpTime (ErrNoUptime).
+*/
+func (rt *RateTracker) Rates(target string, pdus []gosnmp.SnmpPDU) (map[string]float64, error) {
+	uptime, found := time.Duration(0), false
+	for _, pdu := range pdus {
+		if canonicalOID(pdu.Name) == sysUpTimeOID && pdu.Type == gosnmp.TimeTicks {
+			uptime, found = GetAsDuration(pdu), true
+		}
+	}
+	if !found {
+		return nil, ErrNoUptime
+	}
+	rates := make(map[string]float64, len(pdus))
+	var errs []error
+	for _, pdu := range pdus {
+		switch pdu.Type {
+		case gosnmp.Counter32, gosnmp.Counter64:
+			if rate, ok, err := rt.Update(target, uptime, pdu); err != nil {
+				errs = append(errs, err)
+			} else if ok {
+				rates[canonicalOID(pdu.Name)] = rate
+			}
+		case gosnmp.NoSuchObject, gosnmp.NoSuchInstance, gosnmp.EndOfMibView:
+			errs = append(errs, checkVarbind(pdu))
+		}
+	}
+	return rates, errors.Join(errs...)
+}
+
+// Forget drops the samples of a target, such as one which is no longer polled
+func (rt *RateTracker) Forget(target string) {
+	rt.mu.Lock()
+	defer rt.mu.Unlock()
+	delete(rt.uptimes, target)
+	rt.dropSamples(target)
+}
+
+// setUptime notes the latest sysUpTime of the target, dropping its samples if the agent has restarted
+func (rt *RateTracker) setUptime(target string, uptime time.Duration) {
+	if last, found := rt.uptimes[target]; found && uptime < last {
+		rt.dropSamples(target)
+	}
+	rt.uptimes[target] = uptime
+}
+
+// dropSamples deletes all the samples of a target
+func (rt *RateTracker) dropSamples(target string) {
+	for key := range rt.samples {
+		if key.target == target {
+			delete(rt.samples, key)
+		}
+	}
+}
@@ -0,0 +1,83 @@
+package gosnmpHelper
+
+import (
+	"errors"
+	snmp "github.com/gosnmp/gosnmp"
+	"math"
+	"testing"
+	"time"
+)
+
+func TestRateTracker(t *testing.T) {
+	const oid = ".1.3.6.1.2.1.2.2.1.10.2"
+	counter32 := func(v uint) snmp.SnmpPDU { return snmp.SnmpPDU{Name: oid, Type: snmp.Counter32, Value: v} }
+	counter64 := func(v uint64) snmp.SnmpPDU { return snmp.SnmpPDU{Name: oid, Type: snmp.Counter64, Value: v} }
+	tests := []struct {
+		name   string
+		target string
+		uptime time.Duration
+		pdu    snmp.SnmpPDU
+		want   float64
+		wantOk bool
+	}{
+		{name: "First sample", target: "a", uptime: 100 * time.Second, pdu: counter32(1000)},
+		{name: "Rate", target: "a", uptime: 110 * time.Second, pdu: counter32(6000), want: 500, wantOk: true},
+		{name: "Other target", target: "b", uptime: 5 * time.Second, pdu: counter32(0)},
+		{name: "Same sample", target: "a", uptime: 110 * time.Second, pdu: counter32(6000)},
+		{name: "Wrap", target: "a", uptime: 120 * time.Second, pdu: counter32(1000), want: (math.MaxUint32 + 1 - 5000) / 10.0, wantOk: true},
+		{name: "Restart", target: "a", uptime: 2 * time.Second, pdu: counter32(10)},
+		{name: "After restart", target: "a", uptime: 4 * time.Second, pdu: counter32(210), want: 100, wantOk: true},
+		{name: "Type change", target: "a", uptime: 6 * time.Second, pdu: counter64(1000)},
+		{name: "Counter64", target: "a", uptime: 8 * time.Second, pdu: counter64(3000), want: 1000, wantOk: true},
+		{name: "Counter64 reset", target: "a", uptime: 10 * time.Second, pdu: counter64(5)},
+		{name: "Counter64 after reset", target: "a", uptime: 10500 * time.Millisecond, pdu: counter64(105), want: 200, wantOk: true},
+		{name: "Other target continues", target: "b", uptime: 15 * time.Second, pdu: counter32(1000), want: 100, wantOk: true},
+	}
+	tracker := NewRateTracker()
+	for _, tt := range tests {
+		got, ok, err := tracker.Update(tt.target, tt.uptime, tt.pdu)
+		if err != nil || ok != tt.wantOk || got != tt.want {
+			t.Errorf("%s: Update() = %v, %v, %v, want %v, %v", tt.name, got, ok, err, tt.want, tt.wantOk)
+		}
+	}
+	if _, _, err := tracker.Update("a", time.Minute, snmp.SnmpPDU{Name: oid, Type: snmp.Gauge32, Value: uint(1)}); !errors.Is(err, ErrNotCounter) {
+		t.Errorf("Update() of a Gauge32 err = %v, want ErrNotCounter", err)
+	}
+	tracker.Forget("b")
+	if _, ok, _ := tracker.Update("b", 20*time.Second, counter32(2000)); ok {
+		t.Errorf("Update() after Forget() gave a rate")
+	}
+}
+
+func TestRateTrackerRates(t *testing.T) {
+	uptime := func(ticks uint32) snmp.SnmpPDU {
+		return snmp.SnmpPDU{Name: ".1.3.6.1.2.1.1.3.0", Type: snmp.TimeTicks, Value: ticks}
+	}
+	tracker := NewRateTracker()
+	first := []snmp.SnmpPDU{
+		uptime(1000),
+		{Name: "1.3.6.1.2.1.31.1.1.1.6.2", Type: snmp.Counter64, Value: uint64(1e9)},
+		{Name: ".1.3.6.1.2.1.2.2.1.10.2", Type: snmp.Counter32, Value: uint(100)},
+		{Name: ".1.3.6.1.2.1.1.5.0", Type: snmp.OctetString, Value: []byte("router")},
+	}
+	rates, err := tracker.Rates("router", first)
+	if err != nil || len(rates) != 0 {
+		t.Fatalf("Rates() = %v, %v", rates, err)
+	}
+	second := []snmp.SnmpPDU{
+		uptime(1500),
+		{Name: "1.3.6.1.2.1.31.1.1.1.6.2", Type: snmp.Counter64, Value: uint64(1e9 + 5e6)},
+		{Name: ".1.3.6.1.2.1.2.2.1.10.2", Type: snmp.Counter32, Value: uint(600)},
+		{Name: ".1.3.6.1.2.1.2.2.1.10.3", Type: snmp.NoSuchInstance},
+	}
+	rates, err = tracker.Rates("router", second)
+	if !errors.Is(err, ErrNoSuchInstance) {
+		t.Errorf("Rates() err = %v, want ErrNoSuchInstance", err)
+	}
+	if len(rates) != 2 || rates[".1.3.6.1.2.1.31.1.1.1.6.2"] != 1e6 || rates[".1.3.6.1.2.1.2.2.1.10.2"] != 100 {
+		t.Errorf("Rates() = %v", rates)
+	}
+	if _, err = tracker.Rates("router", second[1:]); !errors.Is(err, ErrNoUptime) {
+		t.Errorf("Rates() without sysUpTime err = %v, want ErrNoUptime", err)
+	}
+}
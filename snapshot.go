@@ -0,0 +1,338 @@
+package gosnmpHelper
+
+import (
+	"bufio"
+	"encoding/hex"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"github.com/gosnmp/gosnmp"
+	"io"
+	"reflect"
+	"strconv"
+	"strings"
+	"sync"
+	"time"
+	"unicode/utf8"
+)
+
+/*
+A SnapshotRecord is one line of a snapshot file written by a Recorder.  Snapshots are JSON-lines files, with
+one PDU per line such as:
+
+	{"time":"2026-10-16T09:30:00.123Z","oid":".1.3.6.1.2.1.1.5.0","type":"OctetString","value":"router"}
+	{"time":"2026-10-16T09:30:00.125Z","oid":".1.3.6.1.2.1.2.2.1.6.2","type":"OctetString","hex":"001122334455"}
+	{"time":"2026-10-16T09:30:00.125Z","oid":".1.3.6.1.2.1.2.2.1.10.2","type":"Counter32","value":4000000000}
+
+OctetString and Opaque values which are not printable text are held in Hex instead of Value.
+*/
+type SnapshotRecord struct {
+	Time  time.Time       `json:"time"`
+	OID   string          `json:"oid"`
+	Type  string          `json:"type"`
+	Value json.RawMessage `json:"value,omitempty"`
+	Hex   string          `json:"hex,omitempty"`
+}
+
+// Names of the PDU types in snapshots
+var snapshotTypes = map[gosnmp.Asn1BER]string{
+	gosnmp.Integer:          "Integer",
+	gosnmp.OctetString:      "OctetString",
+	gosnmp.Null:             "Null",
+	gosnmp.ObjectIdentifier: "ObjectIdentifier",
+	gosnmp.IPAddress:        "IPAddress",
+	gosnmp.Counter32:        "Counter32",
+	gosnmp.Gauge32:          "Gauge32",
+	gosnmp.TimeTicks:        "TimeTicks",
+	gosnmp.Opaque:           "Opaque",
+	gosnmp.Counter64:        "Counter64",
+	gosnmp.Uinteger32:       "Uinteger32",
+	gosnmp.OpaqueFloat:      "OpaqueFloat",
+	gosnmp.OpaqueDouble:     "OpaqueDouble",
+	gosnmp.NoSuchObject:     "NoSuchObject",
+	gosnmp.NoSuchInstance:   "NoSuchInstance",
+	gosnmp.EndOfMibView:     "EndOfMibView",
+}
+
+/*
+A Recorder writes every PDU it sees to a snapshot, which can later be fed back through MarshalPDUToStruct()
+with a Replayer.  This allows a misbehaving device to be captured once and the struct mappings debugged
+offline.  For example:
+
+	f, err := os.Create("device.jsonl")
+	...
+	rec := NewRecorder(f)
+	err = rec.BulkWalk(client, ".1.3.6.1.2.1.2.2.1", func(pdu gosnmp.SnmpPDU) error {
+		MarshalPDUToStruct(pdu, &info)
+		return nil
+	})
+
+A Recorder is safe for concurrent use.
+*/
+type Recorder struct {
+	mu  sync.Mutex
+	w   *bufio.Writer
+	now func() time.Time
+}
+
+// NewRecorder returns a Recorder writing a snapshot to w
+func NewRecorder(w io.Writer) *Recorder {
+	return &Recorder{w: bufio.NewWriter(w), now: time.Now}
+}
+
+// Record writes the PDU to the snapshot with the current time
+func (r *Recorder) Record(pdu gosnmp.SnmpPDU) error {
+	rec, err := newSnapshotRecord(pdu)
+	if err != nil {
+		return err
+	}
+	r.mu.Lock()
+	defer r.mu.Unlock()
+	rec.Time = r.now()
+	line, err := json.Marshal(rec)
+	if err != nil {
+		return err
+	}
+	if _, err = r.w.Write(append(line, '\n')); err != nil {
+		return err
+	}
+	return r.w.Flush()
+}
+
+// WalkFunc returns a gosnmp.WalkFunc which records each PDU before passing it to fn, which may be nil
+func (r *Recorder) WalkFunc(fn gosnmp.WalkFunc) gosnmp.WalkFunc {
+	return func(pdu gosnmp.SnmpPDU) error {
+		if err := r.Record(pdu); err != nil {
+			return err
+		}
+		if fn == nil {
+			return nil
+		}
+		return fn(pdu)
+	}
+}
+
+// BulkWalk does client.BulkWalk(), recording each PDU before passing it to fn, which may be nil
+func (r *Recorder) BulkWalk(client *gosnmp.GoSNMP, rootOid string, fn gosnmp.WalkFunc) error {
+	return client.BulkWalk(rootOid, r.WalkFunc(fn))
+}
+
+// Walk does client.Walk(), recording each PDU before passing it to fn, which may be nil
+func (r *Recorder) Walk(client *gosnmp.GoSNMP, rootOid string, fn gosnmp.WalkFunc) error {
+	return client.Walk(rootOid, r.WalkFunc(fn))
+}
+
+// Get does client.Get() and records the PDUs in the result
+func (r *Recorder) Get(client *gosnmp.GoSNMP, oids []string) (*gosnmp.SnmpPacket, error) {
+	result, err := client.Get(oids)
+	if err != nil {
+		return result, err
+	}
+	for _, pdu := range result.Variables {
+		if err = r.Record(pdu); err != nil {
+			return result, err
+		}
+	}
+	return result, nil
+}
+
+// newSnapshotRecord converts a PDU to a snapshot record, without the time
+func newSnapshotRecord(pdu gosnmp.SnmpPDU) (SnapshotRecord, error) {
+	rec := SnapshotRecord{OID: canonicalOID(pdu.Name)}
+	var ok bool
+	if rec.Type, ok = snapshotTypes[pdu.Type]; !ok {
+		return rec, fmt.Errorf("%s: %w: PDU type %v", pdu.Name, ErrInvalidSnapshot, pdu.Type)
+	}
+	var value interface{}
+	switch pdu.Type {
+	case gosnmp.Null, gosnmp.NoSuchObject, gosnmp.NoSuchInstance, gosnmp.EndOfMibView:
+		return rec, nil
+	case gosnmp.OctetString, gosnmp.Opaque:
+		var b []byte
+		switch v := pdu.Value.(type) {
+		case []byte:
+			b = v
+		case string:
+			b = []byte(v)
+		default:
+			return rec, fmt.Errorf("%s: %w: %T value for %s", pdu.Name, ErrInvalidSnapshot, pdu.Value, rec.Type)
+		}
+		if utf8.Valid(b) && !strings.ContainsFunc(string(b), isControl) {
+			value = string(b)
+		} else {
+			rec.Hex = hex.EncodeToString(b)
+			return rec, nil
+		}
+	case gosnmp.ObjectIdentifier, gosnmp.IPAddress:
+		value = fmt.Sprint(pdu.Value)
+	default:
+		v := reflect.ValueOf(pdu.Value)
+		if !v.IsValid() || (!v.CanInt() && !v.CanUint() && !v.CanFloat()) {
+			return rec, fmt.Errorf("%s: %w: %T value for %s", pdu.Name, ErrInvalidSnapshot, pdu.Value, rec.Type)
+		}
+		value = pdu.Value
+	}
+	var err error
+	rec.Value, err = json.Marshal(value)
+	return rec, err
+}
+
+// isControl reports whether the rune is a control character, which makes a string unsuitable for a snapshot
+func isControl(r rune) bool {
+	return (r < ' ' && r != '\t' && r != '\n' && r != '\r') || r == 0x7f
+}
+
+// PDU converts the record back to a PDU holding the Go types gosnmp returns when decoding a response
+func (rec SnapshotRecord) PDU() (gosnmp.SnmpPDU, error) {
+	pdu := gosnmp.SnmpPDU{Name: rec.OID}
+	var found bool
+	for t, name := range snapshotTypes {
+		if name == rec.Type {
+			pdu.Type, found = t, true
+			break
+		}
+	}
+	if !found {
+		return pdu, fmt.Errorf("%s: %w: type %q", rec.OID, ErrInvalidSnapshot, rec.Type)
+	}
+	var err error
+	switch pdu.Type {
+	case gosnmp.Null, gosnmp.NoSuchObject, gosnmp.NoSuchInstance, gosnmp.EndOfMibView:
+	case gosnmp.OctetString, gosnmp.Opaque:
+		if len(rec.Value) == 0 {
+			pdu.Value, err = hex.DecodeString(rec.Hex)
+			break
+		}
+		var s string
+		err = json.Unmarshal(rec.Value, &s)
+		pdu.Value = []byte(s)
+	case gosnmp.ObjectIdentifier, gosnmp.IPAddress:
+		var s string
+		err = json.Unmarshal(rec.Value, &s)
+		pdu.Value = s
+	case gosnmp.Integer:
+		var n int64
+		n, err = strconv.ParseInt(string(rec.Value), 10, 64)
+		pdu.Value = int(n)
+	case gosnmp.Counter32, gosnmp.Gauge32:
+		var n uint64
+		n, err = strconv.ParseUint(string(rec.Value), 10, 32)
+		pdu.Value = uint(n)
+	case gosnmp.TimeTicks, gosnmp.Uinteger32:
+		var n uint64
+		n, err = strconv.ParseUint(string(rec.Value), 10, 32)
+		pdu.Value = uint32(n)
+	case gosnmp.Counter64:
+		pdu.Value, err = strconv.ParseUint(string(rec.Value), 10, 64)
+	case gosnmp.OpaqueFloat:
+		var f float64
+		f, err = strconv.ParseFloat(string(rec.Value), 32)
+		pdu.Value = float32(f)
+	case gosnmp.OpaqueDouble:
+		pdu.Value, err = strconv.ParseFloat(string(rec.Value), 64)
+	}
+	if err != nil {
+		return pdu, fmt.Errorf("%s: %w: %s value %s", rec.OID, ErrInvalidSnapshot, rec.Type, rec.Value)
+	}
+	return pdu, nil
+}
+
+/*
+A Replayer holds the PDUs of a snapshot written by a Recorder and feeds them back through the marshaling
+functions, or through a gosnmp.WalkFunc, as if they came from the device:
+
+	f, err := os.Open("device.jsonl")
+	...
+	replay, err := LoadSnapshot(f)
+	...
+	var info SysInfo
+	err = replay.Replay(&info)
+*/
+type Replayer struct {
+	records []SnapshotRecord
+	pdus    []gosnmp.SnmpPDU
+}
+
+// LoadSnapshot reads a snapshot.  Errors wrap ErrInvalidSnapshot and include the line number.
+func LoadSnapshot(r io.Reader) (*Replayer, error) {
+	p := &Replayer{}
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		if len(strings.TrimSpace(scanner.Text())) == 0 {
+			continue
+		}
+		var rec SnapshotRecord
+		if err := json.Unmarshal(scanner.Bytes(), &rec); err != nil {
+			return nil, fmt.Errorf("line %d: %w: %v", line, ErrInvalidSnapshot, err)
+		}
+		pdu, err := rec.PDU()
+		if err != nil {
+			return nil, fmt.Errorf("line %d: %w", line, err)
+		}
+		p.records = append(p.records, rec)
+		p.pdus = append(p.pdus, pdu)
+	}
+	if err := scanner.Err(); err != nil {
+		return nil, err
+	}
+	return p, nil
+}
+
+// Records returns the records of the snapshot in the order they were recorded
+func (p *Replayer) Records() []SnapshotRecord {
+	return p.records
+}
+
+// PDUs returns the PDUs of the snapshot in the order they were recorded
+func (p *Replayer) PDUs() []gosnmp.SnmpPDU {
+	return p.pdus
+}
+
+// Walk calls fn for each PDU in the snapshot within the rootOid subtree, or all of them if rootOid is "".
+// As with gosnmp, an error returned by fn stops the walk and is returned.
+func (p *Replayer) Walk(rootOid string, fn gosnmp.WalkFunc) error {
+	var root OID
+	if len(rootOid) > 0 {
+		var err error
+		if root, err = ParseOID(rootOid); err != nil {
+			return err
+		}
+	}
+	for _, pdu := range p.pdus {
+		if root != nil {
+			oid, err := ParseOID(pdu.Name)
+			if err != nil || !oid.HasPrefix(root) {
+				continue
+			}
+		}
+		if err := fn(pdu); err != nil {
+			return err
+		}
+	}
+	return nil
+}
+
+/*
+Replay marshals every PDU of the snapshot into dest, which must be a pointer to a struct.  As with Fetch(),
+all the PDUs are tried, noSuchObject, noSuchInstance and endOfMibView values are reported as ErrNoSuchObject,
+ErrNoSuchInstance and ErrEndOfMibView, and the errors are joined.
+*/
+func (p *Replayer) Replay(dest interface{}) error {
+	c, err := destCodec(dest)
+	if err != nil {
+		return err
+	}
+	var errs []error
+	rows := rowIndexes{}
+	for _, pdu := range p.pdus {
+		if err := checkVarbind(pdu); err != nil {
+			errs = append(errs, err)
+			continue
+		}
+		if _, err := c.marshalRows(pdu, dest, rows); err != nil {
+			errs = append(errs, err)
+		}
+	}
+	return errors.Join(errs...)
+}
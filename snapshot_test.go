@@ -0,0 +1,133 @@
+package gosnmpHelper
+
+import (
+	"bytes"
+	"errors"
+	snmp "github.com/gosnmp/gosnmp"
+	"github.com/jjcinaz/gosnmpHelper/snmptest"
+	"reflect"
+	"strings"
+	"testing"
+	"time"
+)
+
+func TestRecorder(t *testing.T) {
+	agent, err := snmptest.NewAgentFromWalkFile("testdata/router.walk")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer agent.Close()
+	client := agent.Client()
+
+	var buf bytes.Buffer
+	rec := NewRecorder(&buf)
+	rec.now = func() time.Time { return time.Date(2026, 10, 16, 9, 30, 0, 0, time.UTC) }
+	var live SysInfo2
+	err = rec.BulkWalk(client, ".1.3.6.1.2.1.2.2.1", func(pdu snmp.SnmpPDU) error {
+		MarshalPDUToStruct(pdu, &live)
+		return nil
+	})
+	if err != nil {
+		t.Fatalf("BulkWalk() err = %v", err)
+	}
+	packet, err := rec.Get(client, GetOidsFromStructTags(&live, false))
+	if err != nil {
+		t.Fatalf("Get() err = %v", err)
+	}
+	MarshalPDUsToStruct(packet.Variables, &live)
+	if err = rec.Record(snmp.SnmpPDU{Name: ".1.3.6.1.2.1.1.99.0", Type: snmp.NoSuchObject}); err != nil {
+		t.Fatalf("Record() err = %v", err)
+	}
+	lines := strings.Split(strings.TrimSpace(buf.String()), "\n")
+	if len(lines) != 27 {
+		t.Errorf("recorded %d lines, want 27", len(lines))
+	}
+	for _, want := range []string{
+		`{"time":"2026-10-16T09:30:00Z","oid":".1.3.6.1.2.1.2.2.1.2.1","type":"OctetString","value":"lo"}`,
+		`{"time":"2026-10-16T09:30:00Z","oid":".1.3.6.1.2.1.2.2.1.6.2","type":"OctetString","hex":"001122334455"}`,
+		`{"time":"2026-10-16T09:30:00Z","oid":".1.3.6.1.2.1.2.2.1.10.2","type":"Counter32","value":4000000000}`,
+		`{"time":"2026-10-16T09:30:00Z","oid":".1.3.6.1.2.1.1.2.0","type":"ObjectIdentifier","value":".1.3.6.1.4.1.8072.3.2.10"}`,
+		`{"time":"2026-10-16T09:30:00Z","oid":".1.3.6.1.2.1.1.99.0","type":"NoSuchObject"}`,
+	} {
+		if !strings.Contains(buf.String(), want+"\n") {
+			t.Errorf("snapshot is missing %s", want)
+		}
+	}
+
+	replay, err := LoadSnapshot(&buf)
+	if err != nil {
+		t.Fatalf("LoadSnapshot() err = %v", err)
+	}
+	var replayed SysInfo2
+	if err = replay.Replay(&replayed); !errors.Is(err, ErrNoSuchObject) {
+		t.Errorf("Replay() err = %v, want ErrNoSuchObject", err)
+	}
+	if !reflect.DeepEqual(replayed, live) {
+		t.Errorf("Replay() = %+v, want %+v", replayed, live)
+	}
+	// The replayed PDUs have the same types and values as the live ones
+	livePdus, err := client.BulkWalkAll(".1.3.6.1.2.1.2.2.1")
+	if err != nil {
+		t.Fatal(err)
+	}
+	var walked []snmp.SnmpPDU
+	err = replay.Walk(".1.3.6.1.2.1.2.2.1", func(pdu snmp.SnmpPDU) error {
+		walked = append(walked, pdu)
+		return nil
+	})
+	if err != nil || !reflect.DeepEqual(walked, livePdus) {
+		t.Errorf("Walk() = %v, %v\nwant %v", walked, err, livePdus)
+	}
+	if got := len(replay.Records()); got != 27 || !replay.Records()[0].Time.Equal(rec.now()) {
+		t.Errorf("Records() = %d records", got)
+	}
+}
+
+func TestSnapshotRecordPDU(t *testing.T) {
+	tests := []struct {
+		name    string
+		pdu     snmp.SnmpPDU
+		wantErr bool
+	}{
+		{name: "Integer", pdu: snmp.SnmpPDU{Name: ".1.3.6.1.2.1.2.2.1.8.1", Type: snmp.Integer, Value: -1}},
+		{name: "Gauge32", pdu: snmp.SnmpPDU{Name: ".1.3.6.1.2.1.2.2.1.5.1", Type: snmp.Gauge32, Value: uint(10)}},
+		{name: "TimeTicks", pdu: snmp.SnmpPDU{Name: ".1.3.6.1.2.1.1.3.0", Type: snmp.TimeTicks, Value: uint32(12345)}},
+		{name: "Counter64", pdu: snmp.SnmpPDU{Name: ".1.3.6.1.2.1.31.1.1.1.6.1", Type: snmp.Counter64, Value: uint64(1) << 63}},
+		{name: "Text with newline", pdu: snmp.SnmpPDU{Name: ".1.3.6.1.2.1.1.1.0", Type: snmp.OctetString, Value: []byte("a\nb")}},
+		{name: "Empty", pdu: snmp.SnmpPDU{Name: ".1.3.6.1.2.1.1.4.0", Type: snmp.OctetString, Value: []byte{}}},
+		{name: "Binary", pdu: snmp.SnmpPDU{Name: ".1.3.6.1.2.1.2.2.1.6.1", Type: snmp.OctetString, Value: []byte{0, 0xff}}},
+		{name: "IPAddress", pdu: snmp.SnmpPDU{Name: ".1.3.6.1.2.1.4.20.1.1.10.0.0.1", Type: snmp.IPAddress, Value: "10.0.0.1"}},
+		{name: "OpaqueFloat", pdu: snmp.SnmpPDU{Name: ".1.3.6.1.4.1.2021.10.1.6.1", Type: snmp.OpaqueFloat, Value: float32(0.15)}},
+		{name: "OpaqueDouble", pdu: snmp.SnmpPDU{Name: ".1.3.6.1.4.1.2021.10.1.6.2", Type: snmp.OpaqueDouble, Value: 2.5}},
+		{name: "EndOfMibView", pdu: snmp.SnmpPDU{Name: ".1.3.6.1.6", Type: snmp.EndOfMibView}},
+		{name: "Wrong Go type", pdu: snmp.SnmpPDU{Name: ".1.3.6.1.2.1.1.3.0", Type: snmp.TimeTicks, Value: "soon"}, wantErr: true},
+		{name: "Wrong string type", pdu: snmp.SnmpPDU{Name: ".1.3.6.1.2.1.1.1.0", Type: snmp.OctetString, Value: 5}, wantErr: true},
+		{name: "Unsupported type", pdu: snmp.SnmpPDU{Name: ".1.3.6.1", Type: snmp.BitString}, wantErr: true},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			rec, err := newSnapshotRecord(tt.pdu)
+			if (err != nil) != tt.wantErr || (err != nil && !errors.Is(err, ErrInvalidSnapshot)) {
+				t.Fatalf("newSnapshotRecord() err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if tt.wantErr {
+				return
+			}
+			got, err := rec.PDU()
+			if err != nil || !reflect.DeepEqual(got, tt.pdu) {
+				t.Errorf("PDU() = %#v, %v, want %#v", got, err, tt.pdu)
+			}
+		})
+	}
+	for _, line := range []string{
+		`{"oid":".1.3.6.1.2.1.1.3.0","type":"TimeTicks","value":-1}`,
+		`{"oid":".1.3.6.1.2.1.1.3.0","type":"Timeticks","value":1}`,
+		`{"oid":".1.3.6.1.2.1.1.1.0","type":"OctetString","hex":"0g"}`,
+		`not json`,
+	} {
+		if _, err := LoadSnapshot(strings.NewReader("\n" + line + "\n")); !errors.Is(err, ErrInvalidSnapshot) ||
+			!strings.HasPrefix(err.Error(), "line 2:") {
+			t.Errorf("LoadSnapshot(%s) err = %v", line, err)
+		}
+	}
+}
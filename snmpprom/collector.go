@@ -0,0 +1,403 @@
+/*
+Package snmpprom exposes structs filled by gosnmpHelper as Prometheus metrics.  Members to be exposed are
+given a metric tag with the metric name and, optionally, its type and labels:
+
+	type IfStats struct {
+		SysUpTime time.Duration     `oid:"SNMPv2-MIB::sysUpTime.0" metric:"snmp_uptime_seconds,gauge"`
+		IfName    map[string]string `oidx:"IF-MIB::ifName.(\\d+)"`
+		InOctets  map[string]uint64 `oidx:"IF-MIB::ifHCInOctets.(\\d+)" metric:"if_in_octets_total,counter,label=ifIndex,label=ifName:IfName"`
+		OutOctets map[string]uint64 `oidx:"IF-MIB::ifHCOutOctets.(\\d+)" metric:"if_out_octets_total,counter,label=ifIndex,label=ifName:IfName"`
+	}
+	collector, err := snmpprom.NewCollector[IfStats](client, prometheus.Labels{"target": client.Target})
+	if err != nil {
+		log.Fatal(err)
+	}
+	prometheus.MustRegister(collector)
+
+The type is counter, gauge or untyped, defaulting to gauge.  Members holding a map give one sample for each
+key, with the key in the label named by a label option with no member, or in an "index" label if there is
+none.  A label option of the form name:Member takes the label's value from another member, which is either
+a map with the same keys, such as the ifName of each interface, or a single value such as sysName.  Where a
+map has no value for a key the key itself is used.
+
+Integers, unsigned integers, floats, bools and Enum types are exposed as they are.  A time.Duration, such as
+sysUpTime, is exposed in seconds.  Only the top level members of the struct are considered; the members of
+nested structs and oidtable rows are not.  An optional help tag gives the metric's help text.
+
+Every Collector also exposes an snmp_scrape_errors gauge, with the constLabels, giving the number of values
+which couldn't be fetched or stored in the last collection.  Collectors registered together must therefore
+have different constLabels.
+*/
+package snmpprom
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/gosnmp/gosnmp"
+	"github.com/jjcinaz/gosnmpHelper"
+	"github.com/prometheus/client_golang/prometheus"
+	"reflect"
+	"regexp"
+	"strings"
+	"sync"
+	"time"
+)
+
+// ErrInvalidTag is returned when a metric tag cannot be parsed or refers to an unknown member
+var ErrInvalidTag = errors.New("invalid metric tag")
+
+// ErrNotNumber is returned when a member with a metric tag does not hold numbers
+var ErrNotNumber = errors.New("metric member does not hold numbers")
+
+// ErrNoMetrics is returned when a struct has no members with metric tags
+var ErrNoMetrics = errors.New("no members with metric tags")
+
+// The default label for the keys of a map
+const indexLabel = "index"
+
+// The gauge counting the values which couldn't be fetched or stored, reserved in every Collector
+const scrapeErrorsName = "snmp_scrape_errors"
+
+var durationType = reflect.TypeOf(time.Duration(0))
+
+// Metric and label names, as allowed by Prometheus
+var nameRx = regexp.MustCompile(`^[a-zA-Z_:][a-zA-Z0-9_:]*$`)
+
+/*
+A Collector is a prometheus.Collector exposing the members with metric tags of a struct of type T.  Each
+time it is collected a new T is filled and its values are exposed.
+*/
+type Collector[T any] struct {
+	// Timeout is the longest filling a T may take, with no limit if zero
+	Timeout time.Duration
+
+	fill         func(ctx context.Context, dest *T) error
+	metrics      []metricField
+	scrapeErrors *prometheus.Desc
+	mu           sync.Mutex
+}
+
+// metricField is a member of T with a metric tag
+type metricField struct {
+	name      string
+	index     int
+	desc      *prometheus.Desc
+	valueType prometheus.ValueType
+	isMap     bool
+	keyLabel  bool  // whether the map key is the first label
+	lookups   []int // member giving the value of each following label
+}
+
+/*
+NewCollector returns a Collector which fills a T from the client with Fetch() and WalkInto() each time it is
+collected, so T may have oid and oidx tags.  The client must already be connected.  Collections are
+serialized so that the client is only used by one at a time, and it must not be used elsewhere while the
+Collector is registered.  The constLabels, which may be nil, are added to every metric; when collecting
+from several targets they tell the targets' metrics apart.
+
+The oid and oidx tags of T are checked here, so an error such as one wrapping gosnmpHelper.ErrUnknownName or
+gosnmpHelper.ErrNoWalkRoot is returned rather than every collection failing.
+
+Values the agent doesn't have, or which can't be stored in their member, are left out and counted in the
+snmp_scrape_errors gauge, and the rest are still exposed.  Other errors, such as a request timing out, are
+reported through prometheus.NewInvalidMetric() so that they're seen by the Gatherer, and no samples are
+sent since members not yet filled would appear to be 0.
+*/
+func NewCollector[T any](client *gosnmp.GoSNMP, constLabels prometheus.Labels) (*Collector[T], error) {
+	codec, err := gosnmpHelper.Compile[T]()
+	if err != nil {
+		return nil, err
+	}
+	if _, err = codec.WalkRoots(); err != nil {
+		return nil, err
+	}
+	return NewCollectorFunc(func(ctx context.Context, dest *T) error {
+		err := gosnmpHelper.Fetch(ctx, client, dest)
+		if err != nil && (ctx.Err() != nil || !onlyValueErrors(err)) {
+			// The agent couldn't be reached, so the walks would only fail the same way
+			return err
+		}
+		return errors.Join(err, gosnmpHelper.WalkInto(ctx, client, dest))
+	}, constLabels)
+}
+
+/*
+NewCollectorFunc returns a Collector which calls fill to fill a T each time it is collected, for values
+gathered some other way, such as with MarshalPDUsToStruct().  Collections are serialized, so fill is only
+called by one at a time.  Errors returned by fill are treated as described for NewCollector(), where values
+the agent doesn't have are those wrapping gosnmpHelper.ErrNoSuchObject, ErrNoSuchInstance or ErrEndOfMibView,
+and values which can't be stored are those with a *gosnmpHelper.FieldError or wrapping ErrConversion.  Only
+values whose error is a *gosnmpHelper.FieldError, as Fetch() and MarshalPDUsToStructE() return, can be left
+out; the member or map entry is found from its Field.
+*/
+func NewCollectorFunc[T any](fill func(ctx context.Context, dest *T) error, constLabels prometheus.Labels) (*Collector[T], error) {
+	t := reflect.TypeOf((*T)(nil)).Elem()
+	if t.Kind() != reflect.Struct {
+		return nil, gosnmpHelper.ErrNotStruct
+	}
+	c := &Collector[T]{
+		fill: fill,
+		scrapeErrors: prometheus.NewDesc(scrapeErrorsName,
+			"Values which could not be fetched or stored in the last collection.", nil, constLabels),
+	}
+	var errs []error
+	for i := 0; i < t.NumField(); i++ {
+		fInfo := t.Field(i)
+		tag, ok := fInfo.Tag.Lookup("metric")
+		if !ok || fInfo.PkgPath != "" {
+			continue
+		}
+		m, err := newMetricField(t, fInfo, tag, constLabels)
+		if err != nil {
+			errs = append(errs, fmt.Errorf("%s: %w", fInfo.Name, err))
+			continue
+		}
+		c.metrics = append(c.metrics, m)
+	}
+	if err := errors.Join(errs...); err != nil {
+		return nil, err
+	}
+	if len(c.metrics) == 0 {
+		return nil, ErrNoMetrics
+	}
+	return c, nil
+}
+
+// newMetricField parses the metric tag of the member fInfo of the struct type t
+func newMetricField(t reflect.Type, fInfo reflect.StructField, tag string, constLabels prometheus.Labels) (metricField, error) {
+	m := metricField{name: fInfo.Name, index: fInfo.Index[0], valueType: prometheus.GaugeValue}
+	valueT := fInfo.Type
+	if valueT.Kind() == reflect.Map {
+		m.isMap = true
+		valueT = valueT.Elem()
+	}
+	if !isNumber(valueT) {
+		return m, fmt.Errorf("%w: %s", ErrNotNumber, fInfo.Type)
+	}
+
+	parts := strings.Split(tag, ",")
+	name := strings.TrimSpace(parts[0])
+	if !nameRx.MatchString(name) {
+		return m, fmt.Errorf("%w: bad metric name %q", ErrInvalidTag, name)
+	}
+	if name == scrapeErrorsName {
+		return m, fmt.Errorf("%w: metric name %s is reserved", ErrInvalidTag, name)
+	}
+	var keyLabel string
+	var labels []string
+	for _, opt := range parts[1:] {
+		opt = strings.TrimSpace(opt)
+		switch opt {
+		case "counter":
+			m.valueType = prometheus.CounterValue
+			continue
+		case "gauge":
+			m.valueType = prometheus.GaugeValue
+			continue
+		case "untyped":
+			m.valueType = prometheus.UntypedValue
+			continue
+		}
+		label, ok := strings.CutPrefix(opt, "label=")
+		if !ok {
+			return m, fmt.Errorf("%w: unknown option %q", ErrInvalidTag, opt)
+		}
+		label, member, hasMember := strings.Cut(label, ":")
+		if !nameRx.MatchString(label) {
+			return m, fmt.Errorf("%w: bad label name %q", ErrInvalidTag, label)
+		}
+		if !hasMember {
+			if !m.isMap || keyLabel != "" {
+				return m, fmt.Errorf("%w: label %s has no member", ErrInvalidTag, label)
+			}
+			keyLabel = label
+			continue
+		}
+		lookup, ok := t.FieldByName(member)
+		if !ok || len(lookup.Index) != 1 || lookup.PkgPath != "" {
+			return m, fmt.Errorf("%w: label %s refers to unknown member %s", ErrInvalidTag, label, member)
+		}
+		if lookup.Type.Kind() == reflect.Map && (!m.isMap || lookup.Type.Key() != fInfo.Type.Key()) {
+			return m, fmt.Errorf("%w: label %s refers to a map with different keys", ErrInvalidTag, label)
+		}
+		labels = append(labels, label)
+		m.lookups = append(m.lookups, lookup.Index[0])
+	}
+	if m.isMap {
+		if keyLabel == "" {
+			keyLabel = indexLabel
+		}
+		m.keyLabel = true
+		labels = append([]string{keyLabel}, labels...)
+	}
+
+	help := fInfo.Tag.Get("help")
+	if help == "" {
+		help = fmt.Sprintf("%s.%s", t.Name(), fInfo.Name)
+	}
+	m.desc = prometheus.NewDesc(name, help, labels, constLabels)
+	return m, nil
+}
+
+// isNumber reports whether values of type t can be exposed as samples
+func isNumber(t reflect.Type) bool {
+	switch t.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64,
+		reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64,
+		reflect.Float32, reflect.Float64, reflect.Bool:
+		return true
+	}
+	return false
+}
+
+// Describe sends the descriptions of all the metrics
+func (c *Collector[T]) Describe(ch chan<- *prometheus.Desc) {
+	for _, m := range c.metrics {
+		ch <- m.desc
+	}
+	ch <- c.scrapeErrors
+}
+
+// Collect fills a new T and sends its values
+func (c *Collector[T]) Collect(ch chan<- prometheus.Metric) {
+	c.mu.Lock()
+	defer c.mu.Unlock()
+	ctx := context.Background()
+	if c.Timeout > 0 {
+		var cancel context.CancelFunc
+		ctx, cancel = context.WithTimeout(ctx, c.Timeout)
+		defer cancel()
+	}
+	dest := new(T)
+	var valueErrs int
+	skip := map[string]bool{} // the Field of each FieldError
+	if err := c.fill(ctx, dest); err != nil {
+		var failed []error
+		for _, err := range splitErrors(err) {
+			if !isValueError(err) {
+				failed = append(failed, err)
+				continue
+			}
+			valueErrs++
+			var fieldErr *gosnmpHelper.FieldError
+			if errors.As(err, &fieldErr) {
+				skip[fieldErr.Field] = true
+			}
+		}
+		if len(failed) > 0 {
+			ch <- prometheus.NewInvalidMetric(c.scrapeErrors, errors.Join(failed...))
+			return
+		}
+	}
+	ch <- prometheus.MustNewConstMetric(c.scrapeErrors, prometheus.GaugeValue, float64(valueErrs))
+	v := reflect.ValueOf(dest).Elem()
+	for _, m := range c.metrics {
+		m.collect(ch, v, skip)
+	}
+}
+
+// splitErrors returns the errors joined into err, with those joined into them in turn
+func splitErrors(err error) []error {
+	joined, ok := err.(interface{ Unwrap() []error })
+	if !ok {
+		return []error{err}
+	}
+	var errs []error
+	for _, e := range joined.Unwrap() {
+		errs = append(errs, splitErrors(e)...)
+	}
+	return errs
+}
+
+// onlyValueErrors reports whether all the errors joined into err concern single values
+func onlyValueErrors(err error) bool {
+	for _, err := range splitErrors(err) {
+		if !isValueError(err) {
+			return false
+		}
+	}
+	return true
+}
+
+// isValueError reports whether err concerns a single value, which the agent doesn't have or which couldn't be
+// stored, rather than the whole collection
+func isValueError(err error) bool {
+	var fieldErr *gosnmpHelper.FieldError
+	return errors.As(err, &fieldErr) || errors.Is(err, gosnmpHelper.ErrConversion) ||
+		errors.Is(err, gosnmpHelper.ErrNoSuchObject) || errors.Is(err, gosnmpHelper.ErrNoSuchInstance) ||
+		errors.Is(err, gosnmpHelper.ErrEndOfMibView)
+}
+
+// collect sends the values of the member from the struct v, except for those whose path, such as "SysUpTime"
+// or "InOctets[3]", is in skip
+func (m *metricField) collect(ch chan<- prometheus.Metric, v reflect.Value, skip map[string]bool) {
+	fv := v.Field(m.index)
+	if !m.isMap {
+		if !skip[m.name] {
+			m.send(ch, number(fv), m.labelValues(v, reflect.Value{}))
+		}
+		return
+	}
+	iter := fv.MapRange()
+	for iter.Next() {
+		if !skip[m.name+"["+labelValue(iter.Key())+"]"] {
+			m.send(ch, number(iter.Value()), m.labelValues(v, iter.Key()))
+		}
+	}
+}
+
+// send sends one sample, or an invalid metric if the label values are not valid UTF-8
+func (m *metricField) send(ch chan<- prometheus.Metric, value float64, labelValues []string) {
+	metric, err := prometheus.NewConstMetric(m.desc, m.valueType, value, labelValues...)
+	if err != nil {
+		metric = prometheus.NewInvalidMetric(m.desc, err)
+	}
+	ch <- metric
+}
+
+// labelValues returns the values of the labels for the map key, which is invalid for a single value
+func (m *metricField) labelValues(v reflect.Value, key reflect.Value) []string {
+	values := make([]string, 0, len(m.lookups)+1)
+	if m.keyLabel {
+		values = append(values, labelValue(key))
+	}
+	for _, i := range m.lookups {
+		lv := v.Field(i)
+		if lv.Kind() == reflect.Map {
+			if lv = lv.MapIndex(key); !lv.IsValid() {
+				lv = key
+			}
+		}
+		values = append(values, labelValue(lv))
+	}
+	return values
+}
+
+// labelValue formats v as a label value
+func labelValue(v reflect.Value) string {
+	if v.Kind() == reflect.Slice && v.Type().Elem().Kind() == reflect.Uint8 {
+		return string(v.Bytes())
+	}
+	return fmt.Sprint(v.Interface())
+}
+
+// number returns v, which must be of a type accepted by isNumber(), as a sample value
+func number(v reflect.Value) float64 {
+	if v.Type() == durationType {
+		return time.Duration(v.Int()).Seconds()
+	}
+	switch v.Kind() {
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		return float64(v.Int())
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		return float64(v.Uint())
+	case reflect.Float32, reflect.Float64:
+		return v.Float()
+	case reflect.Bool:
+		if v.Bool() {
+			return 1
+		}
+	}
+	return 0
+}
@@ -0,0 +1,208 @@
+package snmpprom
+
+import (
+	"context"
+	"errors"
+	"github.com/gosnmp/gosnmp"
+	"github.com/jjcinaz/gosnmpHelper"
+	"github.com/jjcinaz/gosnmpHelper/snmptest"
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/testutil"
+	"strings"
+	"testing"
+	"time"
+)
+
+type ifStats struct {
+	SysName    string                               `oid:".1.3.6.1.2.1.1.5.0"`
+	SysUpTime  time.Duration                        `oid:".1.3.6.1.2.1.1.3.0" metric:"snmp_uptime_seconds" help:"Time since the agent started."`
+	IfDescr    map[string]string                    `oidx:"IF-MIB::ifDescr.(\\d+)"`
+	OperStatus map[string]gosnmpHelper.IfOperStatus `oidx:"IF-MIB::ifOperStatus.(\\d+)" metric:"if_oper_status,label=sysName:SysName"`
+	InOctets   map[string]uint64                    `oidx:"IF-MIB::ifHCInOctets.(\\d+)" metric:"if_in_octets_total,counter,label=ifIndex,label=ifDescr:IfDescr"`
+}
+
+func TestCollector(t *testing.T) {
+	agent := snmptest.NewAgentFromMap(map[string]interface{}{
+		".1.3.6.1.2.1.1.3.0":         time.Duration(12345) * 10 * time.Millisecond,
+		".1.3.6.1.2.1.1.5.0":         "router",
+		".1.3.6.1.2.1.2.2.1.2.1":     "lo",
+		".1.3.6.1.2.1.2.2.1.2.2":     "eth0",
+		".1.3.6.1.2.1.2.2.1.8.1":     1,
+		".1.3.6.1.2.1.2.2.1.8.2":     2,
+		".1.3.6.1.2.1.31.1.1.1.6.1":  uint64(1000),
+		".1.3.6.1.2.1.31.1.1.1.6.2":  uint64(1) << 40,
+		".1.3.6.1.2.1.31.1.1.1.6.10": uint64(5),
+	})
+	defer agent.Close()
+	collector, err := NewCollector[ifStats](agent.Client(), prometheus.Labels{"target": "router"})
+	if err != nil {
+		t.Fatalf("NewCollector() err = %v", err)
+	}
+	collector.Timeout = 5 * time.Second
+	want := `
+# HELP if_in_octets_total ifStats.InOctets
+# TYPE if_in_octets_total counter
+if_in_octets_total{ifDescr="10",ifIndex="10",target="router"} 5
+if_in_octets_total{ifDescr="eth0",ifIndex="2",target="router"} 1.099511627776e+12
+if_in_octets_total{ifDescr="lo",ifIndex="1",target="router"} 1000
+# HELP if_oper_status ifStats.OperStatus
+# TYPE if_oper_status gauge
+if_oper_status{index="1",sysName="router",target="router"} 1
+if_oper_status{index="2",sysName="router",target="router"} 2
+# HELP snmp_scrape_errors Values which could not be fetched or stored in the last collection.
+# TYPE snmp_scrape_errors gauge
+snmp_scrape_errors{target="router"} 0
+# HELP snmp_uptime_seconds Time since the agent started.
+# TYPE snmp_uptime_seconds gauge
+snmp_uptime_seconds{target="router"} 123.45
+`
+	if err := testutil.CollectAndCompare(collector, strings.NewReader(want)); err != nil {
+		t.Error(err)
+	}
+
+	// A value the agent doesn't have is left out, not exposed as 0
+	sparse := snmptest.NewAgentFromMap(map[string]interface{}{".1.3.6.1.2.1.1.5.0": "router"})
+	defer sparse.Close()
+	collector, err = NewCollector[ifStats](sparse.Client(), nil)
+	if err != nil {
+		t.Fatalf("NewCollector() err = %v", err)
+	}
+	if n := testutil.CollectAndCount(collector, "snmp_uptime_seconds"); n != 0 {
+		t.Errorf("collected %d snmp_uptime_seconds samples from agent without sysUpTime, want 0", n)
+	}
+}
+
+func TestCollectorFunc(t *testing.T) {
+	fillErr := errors.New("no response")
+	collector, err := NewCollectorFunc(func(ctx context.Context, dest *ifStats) error {
+		return errors.Join(gosnmpHelper.MarshalPDUsToStructE([]gosnmp.SnmpPDU{
+			{Name: ".1.3.6.1.2.1.2.2.1.8.3", Type: gosnmp.Integer, Value: 1},
+			{Name: ".1.3.6.1.2.1.31.1.1.1.6.3", Type: gosnmp.OctetString, Value: []byte("many")},
+			{Name: ".1.3.6.1.2.1.31.1.1.1.6.4", Type: gosnmp.Counter64, Value: uint64(7)},
+		}, dest), &gosnmpHelper.FieldError{Field: "SysUpTime", OID: ".1.3.6.1.2.1.1.3.0", Err: gosnmpHelper.ErrNoSuchInstance})
+	}, nil)
+	if err != nil {
+		t.Fatalf("NewCollectorFunc() err = %v", err)
+	}
+	if n := testutil.CollectAndCount(collector, "if_oper_status"); n != 1 {
+		t.Errorf("collected %d if_oper_status samples, want 1", n)
+	}
+	// The missing and unconvertible values don't fail the scrape, and are left out rather than exposed as 0
+	want := `
+# HELP if_in_octets_total ifStats.InOctets
+# TYPE if_in_octets_total counter
+if_in_octets_total{ifDescr="4",ifIndex="4"} 7
+# HELP snmp_scrape_errors Values which could not be fetched or stored in the last collection.
+# TYPE snmp_scrape_errors gauge
+snmp_scrape_errors 2
+`
+	if err := testutil.CollectAndCompare(collector, strings.NewReader(want), "if_in_octets_total", "snmp_scrape_errors", "snmp_uptime_seconds"); err != nil {
+		t.Error(err)
+	}
+
+	collector, err = NewCollectorFunc(func(ctx context.Context, dest *ifStats) error {
+		return fillErr
+	}, nil)
+	if err != nil {
+		t.Fatalf("NewCollectorFunc() err = %v", err)
+	}
+	registry := prometheus.NewPedanticRegistry()
+	registry.MustRegister(collector)
+	if _, err := registry.Gather(); !errors.Is(err, fillErr) {
+		t.Errorf("Gather() err = %v, want %v", err, fillErr)
+	}
+	// Only the error is sent, not the members left at 0
+	ch := make(chan prometheus.Metric, 10)
+	collector.Collect(ch)
+	close(ch)
+	if n := len(ch); n != 1 {
+		t.Errorf("Collect() after failure sent %d metrics, want 1", n)
+	}
+}
+
+func TestCollectorStoppedAgent(t *testing.T) {
+	agent := snmptest.NewAgent(nil)
+	collector, err := NewCollector[ifStats](agent.Client(), nil)
+	agent.Close()
+	if err != nil {
+		t.Fatalf("NewCollector() err = %v", err)
+	}
+	collector.Timeout = 100 * time.Millisecond
+	registry := prometheus.NewPedanticRegistry()
+	registry.MustRegister(collector)
+	_, err = registry.Gather()
+	if err == nil {
+		t.Fatalf("Gather() from stopped agent succeeded")
+	}
+	// The walks aren't attempted once the Gets have failed
+	if strings.Contains(err.Error(), "walk ") {
+		t.Errorf("Gather() err = %v, want no walk errors", err)
+	}
+}
+
+// collectorErr returns the error from NewCollectorFunc() for the type T
+func collectorErr[T any]() error {
+	_, err := NewCollectorFunc(func(ctx context.Context, dest *T) error { return nil }, nil)
+	return err
+}
+
+func TestNewCollectorErrors(t *testing.T) {
+	type badName struct {
+		Value int `metric:"bad-name"`
+	}
+	type badOption struct {
+		Value int `metric:"value,summary"`
+	}
+	type notNumber struct {
+		Value map[string]string `metric:"value"`
+	}
+	type unknownMember struct {
+		Value map[string]int `metric:"value,label=name:Name"`
+	}
+	type otherKeys struct {
+		Names map[int]string
+		Value map[string]int `metric:"value,label=name:Names"`
+	}
+	type keyLabelOnScalar struct {
+		Value int `metric:"value,label=index"`
+	}
+	type reservedName struct {
+		Value int `metric:"snmp_scrape_errors"`
+	}
+	type noMetrics struct {
+		Value int `oid:".1.3.6.1.2.1.2.1.0"`
+	}
+	tests := []struct {
+		name string
+		err  error
+		want error
+	}{
+		{"bad name", collectorErr[badName](), ErrInvalidTag},
+		{"bad option", collectorErr[badOption](), ErrInvalidTag},
+		{"not number", collectorErr[notNumber](), ErrNotNumber},
+		{"unknown member", collectorErr[unknownMember](), ErrInvalidTag},
+		{"other keys", collectorErr[otherKeys](), ErrInvalidTag},
+		{"key label on scalar", collectorErr[keyLabelOnScalar](), ErrInvalidTag},
+		{"reserved name", collectorErr[reservedName](), ErrInvalidTag},
+		{"no metrics", collectorErr[noMetrics](), ErrNoMetrics},
+		{"not struct", collectorErr[int](), gosnmpHelper.ErrNotStruct},
+	}
+	for _, tt := range tests {
+		if !errors.Is(tt.err, tt.want) {
+			t.Errorf("%s: err = %v, want %v", tt.name, tt.err, tt.want)
+		}
+	}
+
+	type unknownName struct {
+		Value int `oid:"NO-SUCH-MIB::foo.0" metric:"value"`
+	}
+	type noWalkRoot struct {
+		Value map[string]int `oidx:"(\\d+)" metric:"value"`
+	}
+	if _, err := NewCollector[unknownName](nil, nil); !errors.Is(err, gosnmpHelper.ErrUnknownName) {
+		t.Errorf("NewCollector() err = %v, want ErrUnknownName", err)
+	}
+	if _, err := NewCollector[noWalkRoot](nil, nil); !errors.Is(err, gosnmpHelper.ErrNoWalkRoot) {
+		t.Errorf("NewCollector() err = %v, want ErrNoWalkRoot", err)
+	}
+}
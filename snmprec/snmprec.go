@@ -0,0 +1,298 @@
+/*
+Package snmprec reads and writes the .snmprec files used by snmpsim, so that device fixtures can be shared
+between snmpsim and Go tests.  Each line of a file holds one value:
+
+	1.3.6.1.2.1.1.1.0|4|Linux router 5.10.0
+	1.3.6.1.2.1.1.3.0|67|7654321
+	1.3.6.1.2.1.2.2.1.6.2|4x|001122334455
+
+The middle field is the ASN.1 tag of the value type as a decimal number.  An x after the tag means the value
+is hex encoded.  The PDUs read hold the same Go types gosnmp returns when decoding a response.
+*/
+package snmprec
+
+import (
+	"bufio"
+	"bytes"
+	"encoding/binary"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"github.com/gosnmp/gosnmp"
+	"github.com/jjcinaz/gosnmpHelper/internal/oids"
+	"io"
+	"math"
+	"net"
+	"os"
+	"sort"
+	"strconv"
+	"strings"
+)
+
+// ErrSyntax is returned when a line is not in the snmprec format
+var ErrSyntax = errors.New("invalid snmprec record")
+
+// ErrUnsupportedType is returned for value types which cannot be converted
+var ErrUnsupportedType = errors.New("unsupported snmprec value type")
+
+// Opaque values holding a float or double are BER encoded within the Opaque, as net-snmp does
+var (
+	opaqueFloatPrefix  = []byte{0x9f, byte(gosnmp.OpaqueFloat), 4}
+	opaqueDoublePrefix = []byte{0x9f, byte(gosnmp.OpaqueDouble), 8}
+)
+
+// ReadFile reads the named snmprec file.  See Read().
+func ReadFile(filename string) ([]gosnmp.SnmpPDU, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	pdus, err := Read(f)
+	if err != nil {
+		return pdus, fmt.Errorf("%s: %w", filename, err)
+	}
+	return pdus, nil
+}
+
+/*
+Read reads records in the snmprec format.  Blank lines and lines starting with # are skipped.  The tags are
+converted as follows:
+
+	2   Integer, int
+	4   OctetString, []byte
+	5   Null, nil
+	6   ObjectIdentifier, string
+	64  IPAddress, string
+	65  Counter32, uint
+	66  Gauge32, uint
+	67  TimeTicks, uint32
+	68  Opaque, []byte, or OpaqueFloat, float32 and OpaqueDouble, float64 for values encoded as such
+	70  Counter64, uint64
+	71  Uinteger32, uint32
+	128 NoSuchObject, 129 NoSuchInstance and 130 EndOfMibView, nil
+
+Records using snmpsim variation modules, such as "1.3.6.1.2.1.1.3.0|67:numeric|...", are not supported.
+Errors wrap ErrSyntax or ErrUnsupportedType and include the line number.  The PDUs before the line in error
+are returned with the error.
+*/
+func Read(r io.Reader) ([]gosnmp.SnmpPDU, error) {
+	var pdus []gosnmp.SnmpPDU
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for line := 1; scanner.Scan(); line++ {
+		text := strings.TrimRight(scanner.Text(), "\r")
+		if len(strings.TrimSpace(text)) == 0 || strings.HasPrefix(text, "#") {
+			continue
+		}
+		pdu, err := ParseRecord(text)
+		if err != nil {
+			return pdus, fmt.Errorf("line %d: %w", line, err)
+		}
+		pdus = append(pdus, pdu)
+	}
+	return pdus, scanner.Err()
+}
+
+// ParseRecord converts one snmprec record to a PDU.  See Read().
+func ParseRecord(record string) (gosnmp.SnmpPDU, error) {
+	var pdu gosnmp.SnmpPDU
+	fields := strings.SplitN(record, "|", 3)
+	if len(fields) != 3 {
+		return pdu, fmt.Errorf("%w: %q", ErrSyntax, record)
+	}
+	oid, err := oids.Parse(fields[0])
+	if err != nil {
+		return pdu, fmt.Errorf("%w: %v", ErrSyntax, err)
+	}
+	pdu.Name = oids.Format(oid)
+	tag, value := fields[1], fields[2]
+	isHex := strings.HasSuffix(tag, "x")
+	tag = strings.TrimSuffix(tag, "x")
+	n, err := strconv.ParseUint(tag, 10, 8)
+	if err != nil {
+		return pdu, fmt.Errorf("%w: tag %q", ErrUnsupportedType, fields[1])
+	}
+	pdu.Type = gosnmp.Asn1BER(n)
+	var raw []byte
+	if isHex {
+		if raw, err = hex.DecodeString(value); err != nil {
+			return pdu, fmt.Errorf("%w: invalid hex value %q", ErrSyntax, value)
+		}
+	} else {
+		raw = []byte(value)
+	}
+	switch pdu.Type {
+	case gosnmp.Integer:
+		var i int64
+		i, err = strconv.ParseInt(string(raw), 10, 32)
+		pdu.Value = int(i)
+	case gosnmp.OctetString:
+		pdu.Value = raw
+	case gosnmp.Null, gosnmp.NoSuchObject, gosnmp.NoSuchInstance, gosnmp.EndOfMibView:
+	case gosnmp.ObjectIdentifier:
+		var o []uint32
+		o, err = oids.Parse(string(raw))
+		pdu.Value = oids.Format(o)
+	case gosnmp.IPAddress:
+		ip := net.IP(raw)
+		if len(raw) != net.IPv4len && len(raw) != net.IPv6len {
+			ip = net.ParseIP(string(raw))
+		}
+		if ip == nil {
+			err = ErrSyntax
+		} else {
+			pdu.Value = ip.String()
+		}
+	case gosnmp.Counter32, gosnmp.Gauge32:
+		var u uint64
+		u, err = strconv.ParseUint(string(raw), 10, 32)
+		pdu.Value = uint(u)
+	case gosnmp.TimeTicks, gosnmp.Uinteger32:
+		var u uint64
+		u, err = strconv.ParseUint(string(raw), 10, 32)
+		pdu.Value = uint32(u)
+	case gosnmp.Counter64:
+		pdu.Value, err = strconv.ParseUint(string(raw), 10, 64)
+	case gosnmp.Opaque:
+		switch {
+		case len(raw) == 7 && bytes.HasPrefix(raw, opaqueFloatPrefix):
+			pdu.Type, pdu.Value = gosnmp.OpaqueFloat, math.Float32frombits(binary.BigEndian.Uint32(raw[3:]))
+		case len(raw) == 11 && bytes.HasPrefix(raw, opaqueDoublePrefix):
+			pdu.Type, pdu.Value = gosnmp.OpaqueDouble, math.Float64frombits(binary.BigEndian.Uint64(raw[3:]))
+		default:
+			pdu.Value = raw
+		}
+	default:
+		return pdu, fmt.Errorf("%w: tag %q", ErrUnsupportedType, fields[1])
+	}
+	if err != nil {
+		return pdu, fmt.Errorf("%w: invalid value %q for tag %s", ErrSyntax, value, fields[1])
+	}
+	return pdu, nil
+}
+
+// WriteFile writes the PDUs to the named snmprec file.  See Write().
+func WriteFile(filename string, pdus []gosnmp.SnmpPDU) error {
+	f, err := os.Create(filename)
+	if err != nil {
+		return err
+	}
+	if err = Write(f, pdus); err != nil {
+		f.Close()
+		return err
+	}
+	return f.Close()
+}
+
+/*
+Write writes the PDUs in the snmprec format, sorted into SNMP order as snmpsim requires.  The PDU values may
+be of the types gosnmp returns when decoding or those it accepts when encoding, so either live results or
+PDUs from Read() can be written.  OctetStrings which are not printable text are written hex encoded, as are
+IPAddress values and Opaque values.  OpaqueFloat and OpaqueDouble are written as Opaque values which Read()
+converts back.
+
+An error wrapping ErrUnsupportedType is returned for PDU types with no snmprec tag, and ErrSyntax for PDUs
+with invalid names or values of the wrong Go type.  Nothing is written in either case.
+*/
+func Write(w io.Writer, pdus []gosnmp.SnmpPDU) error {
+	type record struct {
+		oid  []uint32
+		text string
+	}
+	records := make([]record, 0, len(pdus))
+	for _, pdu := range pdus {
+		oid, err := oids.Parse(pdu.Name)
+		if err != nil {
+			return fmt.Errorf("%w: %v", ErrSyntax, err)
+		}
+		tag, value, err := formatValue(pdu)
+		if err != nil {
+			return fmt.Errorf("%s: %w", pdu.Name, err)
+		}
+		records = append(records, record{oid: oid, text: oids.Format(oid)[1:] + "|" + tag + "|" + value + "\n"})
+	}
+	sort.SliceStable(records, func(i, j int) bool { return oids.Compare(records[i].oid, records[j].oid) < 0 })
+	bw := bufio.NewWriter(w)
+	for _, r := range records {
+		if _, err := bw.WriteString(r.text); err != nil {
+			return err
+		}
+	}
+	return bw.Flush()
+}
+
+// formatValue returns the tag and value fields of the record for a PDU
+func formatValue(pdu gosnmp.SnmpPDU) (string, string, error) {
+	tag := strconv.Itoa(int(pdu.Type))
+	switch pdu.Type {
+	case gosnmp.Integer, gosnmp.Counter32, gosnmp.Gauge32, gosnmp.TimeTicks, gosnmp.Counter64, gosnmp.Uinteger32:
+		switch pdu.Value.(type) {
+		case int, int8, int16, int32, int64, uint, uint8, uint16, uint32, uint64:
+			return tag, fmt.Sprint(pdu.Value), nil
+		}
+	case gosnmp.OctetString:
+		b, ok := pdu.Value.([]byte)
+		if s, isString := pdu.Value.(string); isString {
+			b, ok = []byte(s), true
+		}
+		if !ok {
+			break
+		}
+		if isPrintable(b) {
+			return tag, string(b), nil
+		}
+		return tag + "x", hex.EncodeToString(b), nil
+	case gosnmp.Null, gosnmp.NoSuchObject, gosnmp.NoSuchInstance, gosnmp.EndOfMibView:
+		return tag, "", nil
+	case gosnmp.ObjectIdentifier:
+		if v, ok := pdu.Value.(string); ok {
+			if oid, err := oids.Parse(v); err == nil {
+				return tag, oids.Format(oid)[1:], nil
+			}
+		}
+	case gosnmp.IPAddress:
+		var ip net.IP
+		switch v := pdu.Value.(type) {
+		case string:
+			ip = net.ParseIP(v)
+		case []byte:
+			ip = net.IP(v)
+		case net.IP:
+			ip = v
+		}
+		if ip4 := ip.To4(); ip4 != nil {
+			return tag + "x", hex.EncodeToString(ip4), nil
+		} else if len(ip) == net.IPv6len {
+			return tag + "x", hex.EncodeToString(ip), nil
+		}
+	case gosnmp.Opaque:
+		if v, ok := pdu.Value.([]byte); ok {
+			return tag + "x", hex.EncodeToString(v), nil
+		}
+	case gosnmp.OpaqueFloat:
+		if v, ok := pdu.Value.(float32); ok {
+			b := binary.BigEndian.AppendUint32(append([]byte{}, opaqueFloatPrefix...), math.Float32bits(v))
+			return strconv.Itoa(int(gosnmp.Opaque)) + "x", hex.EncodeToString(b), nil
+		}
+	case gosnmp.OpaqueDouble:
+		if v, ok := pdu.Value.(float64); ok {
+			b := binary.BigEndian.AppendUint64(append([]byte{}, opaqueDoublePrefix...), math.Float64bits(v))
+			return strconv.Itoa(int(gosnmp.Opaque)) + "x", hex.EncodeToString(b), nil
+		}
+	default:
+		return "", "", fmt.Errorf("%w: %v", ErrUnsupportedType, pdu.Type)
+	}
+	return "", "", fmt.Errorf("%w: %T value for %v", ErrSyntax, pdu.Value, pdu.Type)
+}
+
+// isPrintable reports whether the bytes can be written as they are, rather than hex encoded
+func isPrintable(b []byte) bool {
+	for _, c := range b {
+		if c < ' ' || c > '~' {
+			return false
+		}
+	}
+	// Leading or trailing spaces would be lost by some editors
+	return len(bytes.TrimSpace(b)) == len(b)
+}
@@ -0,0 +1,148 @@
+package snmprec
+
+import (
+	"bytes"
+	"errors"
+	"github.com/gosnmp/gosnmp"
+	"path/filepath"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseRecord(t *testing.T) {
+	tests := []struct {
+		name    string
+		record  string
+		want    gosnmp.SnmpPDU
+		wantErr error
+	}{
+		{name: "String", record: "1.3.6.1.2.1.1.1.0|4|Linux router",
+			want: gosnmp.SnmpPDU{Name: ".1.3.6.1.2.1.1.1.0", Type: gosnmp.OctetString, Value: []byte("Linux router")}},
+		{name: "String with bar", record: "1.3.6.1.2.1.1.1.0|4|a|b",
+			want: gosnmp.SnmpPDU{Name: ".1.3.6.1.2.1.1.1.0", Type: gosnmp.OctetString, Value: []byte("a|b")}},
+		{name: "Hex string", record: "1.3.6.1.2.1.2.2.1.6.2|4x|001122aabbcc",
+			want: gosnmp.SnmpPDU{Name: ".1.3.6.1.2.1.2.2.1.6.2", Type: gosnmp.OctetString, Value: []byte{0, 0x11, 0x22, 0xaa, 0xbb, 0xcc}}},
+		{name: "Integer", record: "1.3.6.1.2.1.2.2.1.8.1|2|-1",
+			want: gosnmp.SnmpPDU{Name: ".1.3.6.1.2.1.2.2.1.8.1", Type: gosnmp.Integer, Value: -1}},
+		{name: "Null", record: "1.3.6.1.2.1.1.8.0|5|",
+			want: gosnmp.SnmpPDU{Name: ".1.3.6.1.2.1.1.8.0", Type: gosnmp.Null}},
+		{name: "OID", record: "1.3.6.1.2.1.1.2.0|6|1.3.6.1.4.1.8072.3.2.10",
+			want: gosnmp.SnmpPDU{Name: ".1.3.6.1.2.1.1.2.0", Type: gosnmp.ObjectIdentifier, Value: ".1.3.6.1.4.1.8072.3.2.10"}},
+		{name: "IpAddress", record: "1.3.6.1.2.1.4.20.1.1.10.0.0.1|64|10.0.0.1",
+			want: gosnmp.SnmpPDU{Name: ".1.3.6.1.2.1.4.20.1.1.10.0.0.1", Type: gosnmp.IPAddress, Value: "10.0.0.1"}},
+		{name: "Hex IpAddress", record: "1.3.6.1.2.1.4.20.1.1.10.0.0.1|64x|0a000001",
+			want: gosnmp.SnmpPDU{Name: ".1.3.6.1.2.1.4.20.1.1.10.0.0.1", Type: gosnmp.IPAddress, Value: "10.0.0.1"}},
+		{name: "Counter32", record: "1.3.6.1.2.1.2.2.1.10.2|65|4000000000",
+			want: gosnmp.SnmpPDU{Name: ".1.3.6.1.2.1.2.2.1.10.2", Type: gosnmp.Counter32, Value: uint(4000000000)}},
+		{name: "Gauge32", record: "1.3.6.1.2.1.2.2.1.5.2|66|1000000000",
+			want: gosnmp.SnmpPDU{Name: ".1.3.6.1.2.1.2.2.1.5.2", Type: gosnmp.Gauge32, Value: uint(1000000000)}},
+		{name: "TimeTicks", record: "1.3.6.1.2.1.1.3.0|67|7654321",
+			want: gosnmp.SnmpPDU{Name: ".1.3.6.1.2.1.1.3.0", Type: gosnmp.TimeTicks, Value: uint32(7654321)}},
+		{name: "Opaque", record: "1.3.6.1.4.1.99.1.0|68x|0102",
+			want: gosnmp.SnmpPDU{Name: ".1.3.6.1.4.1.99.1.0", Type: gosnmp.Opaque, Value: []byte{1, 2}}},
+		{name: "Opaque float", record: "1.3.6.1.4.1.2021.10.1.6.1|68x|9f78043e19999a",
+			want: gosnmp.SnmpPDU{Name: ".1.3.6.1.4.1.2021.10.1.6.1", Type: gosnmp.OpaqueFloat, Value: float32(0.15)}},
+		{name: "Counter64", record: "1.3.6.1.2.1.31.1.1.1.6.2|70|98765432109876",
+			want: gosnmp.SnmpPDU{Name: ".1.3.6.1.2.1.31.1.1.1.6.2", Type: gosnmp.Counter64, Value: uint64(98765432109876)}},
+		{name: "No such instance", record: "1.3.6.1.2.1.1.1.1|129|",
+			want: gosnmp.SnmpPDU{Name: ".1.3.6.1.2.1.1.1.1", Type: gosnmp.NoSuchInstance}},
+		{name: "Missing field", record: "1.3.6.1.2.1.1.1.0|4", wantErr: ErrSyntax},
+		{name: "Bad OID", record: "1.3.six|4|x", wantErr: ErrSyntax},
+		{name: "Bad hex", record: "1.3.6.1.2.1.1.1.0|4x|0g", wantErr: ErrSyntax},
+		{name: "Bad integer", record: "1.3.6.1.2.1.1.7.0|2|72.5", wantErr: ErrSyntax},
+		{name: "Bad IpAddress", record: "1.3.6.1.2.1.4.20.1.1.10.0.0.1|64|10.0.0", wantErr: ErrSyntax},
+		{name: "Variation module", record: "1.3.6.1.2.1.1.3.0|67:numeric|rate=100", wantErr: ErrUnsupportedType},
+		{name: "Unknown tag", record: "1.3.6.1.2.1.1.3.0|99|1", wantErr: ErrUnsupportedType},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseRecord(tt.record)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("ParseRecord() err = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseRecord() err = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseRecord() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWrite(t *testing.T) {
+	pdus := []gosnmp.SnmpPDU{
+		{Name: ".1.3.6.1.2.1.2.2.1.10.2", Type: gosnmp.Counter32, Value: uint(4000000000)},
+		{Name: ".1.3.6.1.2.1.1.3.0", Type: gosnmp.TimeTicks, Value: uint32(7654321)},
+		{Name: "1.3.6.1.2.1.1.1.0", Type: gosnmp.OctetString, Value: "Linux router"},
+		{Name: ".1.3.6.1.2.1.1.2.0", Type: gosnmp.ObjectIdentifier, Value: ".1.3.6.1.4.1.8072.3.2.10"},
+		{Name: ".1.3.6.1.2.1.2.2.1.6.2", Type: gosnmp.OctetString, Value: []byte{0, 0x11, 0x22, 0xaa, 0xbb, 0xcc}},
+		{Name: ".1.3.6.1.2.1.2.2.1.2.2", Type: gosnmp.OctetString, Value: []byte(" padded ")},
+		{Name: ".1.3.6.1.2.1.2.2.1.8.1", Type: gosnmp.Integer, Value: -1},
+		{Name: ".1.3.6.1.2.1.4.20.1.1.10.0.0.1", Type: gosnmp.IPAddress, Value: "10.0.0.1"},
+		{Name: ".1.3.6.1.2.1.31.1.1.1.6.2", Type: gosnmp.Counter64, Value: uint64(98765432109876)},
+		{Name: ".1.3.6.1.4.1.2021.10.1.6.1", Type: gosnmp.OpaqueFloat, Value: float32(0.15)},
+		{Name: ".1.3.6.1.4.1.2021.10.1.6.2", Type: gosnmp.OpaqueDouble, Value: 2.5},
+		{Name: ".1.3.6.1.2.1.1.1.1", Type: gosnmp.NoSuchInstance},
+	}
+	want := `1.3.6.1.2.1.1.1.0|4|Linux router
+1.3.6.1.2.1.1.1.1|129|
+1.3.6.1.2.1.1.2.0|6|1.3.6.1.4.1.8072.3.2.10
+1.3.6.1.2.1.1.3.0|67|7654321
+1.3.6.1.2.1.2.2.1.2.2|4x|2070616464656420
+1.3.6.1.2.1.2.2.1.6.2|4x|001122aabbcc
+1.3.6.1.2.1.2.2.1.8.1|2|-1
+1.3.6.1.2.1.2.2.1.10.2|65|4000000000
+1.3.6.1.2.1.4.20.1.1.10.0.0.1|64x|0a000001
+1.3.6.1.2.1.31.1.1.1.6.2|70|98765432109876
+1.3.6.1.4.1.2021.10.1.6.1|68x|9f78043e19999a
+1.3.6.1.4.1.2021.10.1.6.2|68x|9f79084004000000000000
+`
+	var buf bytes.Buffer
+	if err := Write(&buf, pdus); err != nil {
+		t.Fatalf("Write() err = %v", err)
+	}
+	if got := buf.String(); got != want {
+		t.Errorf("Write() =\n%s\nwant\n%s", got, want)
+	}
+
+	// Reading back gives the same types and values as were written
+	filename := filepath.Join(t.TempDir(), "device.snmprec")
+	if err := WriteFile(filename, pdus); err != nil {
+		t.Fatalf("WriteFile() err = %v", err)
+	}
+	read, err := ReadFile(filename)
+	if err != nil {
+		t.Fatalf("ReadFile() err = %v", err)
+	}
+	byName := map[string]gosnmp.SnmpPDU{}
+	for _, pdu := range read {
+		byName[pdu.Name] = pdu
+	}
+	for _, pdu := range pdus {
+		got := byName["."+strings.TrimPrefix(pdu.Name, ".")]
+		if s, ok := pdu.Value.(string); ok && pdu.Type == gosnmp.OctetString {
+			pdu.Value = []byte(s)
+		}
+		if got.Type != pdu.Type || !reflect.DeepEqual(got.Value, pdu.Value) {
+			t.Errorf("ReadFile() = %#v, want %#v", got, pdu)
+		}
+	}
+
+	for _, bad := range []gosnmp.SnmpPDU{
+		{Name: ".1.3.6.1.2.1.1.1.0", Type: gosnmp.OctetString, Value: 5},
+		{Name: ".1.3.6.1.2.1.1.3.0", Type: gosnmp.TimeTicks, Value: "soon"},
+		{Name: ".1.3.six", Type: gosnmp.Integer, Value: 1},
+	} {
+		if err := Write(&buf, []gosnmp.SnmpPDU{bad}); !errors.Is(err, ErrSyntax) {
+			t.Errorf("Write(%v) err = %v, want ErrSyntax", bad, err)
+		}
+	}
+	if err := Write(&buf, []gosnmp.SnmpPDU{{Name: ".1.3.6.1", Type: gosnmp.BitString}}); !errors.Is(err, ErrUnsupportedType) {
+		t.Errorf("Write() of BitString err = %v, want ErrUnsupportedType", err)
+	}
+}
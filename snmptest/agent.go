@@ -0,0 +1,402 @@
+/*
+Package snmptest provides a fake SNMP agent for testing code which uses gosnmp, such as Fetch() and
+WalkInto() in gosnmpHelper, without any real devices.
+
+The agent listens on a UDP port on the loopback interface and answers SNMPv1 and SNMPv2c GET, GETNEXT
+and GETBULK requests from a fixed set of PDUs, which can be loaded from a map, from `snmpwalk -On` output
+or from an snmpsim .snmprec file:
+
+	agent := snmptest.NewAgentFromMap(map[string]interface{}{
+		".1.3.6.1.2.1.1.1.0": "Test device",
+		".1.3.6.1.2.1.1.3.0": time.Duration(12345) * 10 * time.Millisecond,
+		".1.3.6.1.2.1.2.2.1.2.1": "lo",
+	})
+	defer agent.Close()
+	client := agent.Client()
+	err := gosnmpHelper.Fetch(ctx, client, &info)
+
+SNMPv3 requests are ignored and SET requests are refused as not writable.
+*/
+package snmptest
+
+import (
+	"fmt"
+	"github.com/gosnmp/gosnmp"
+	"github.com/jjcinaz/gosnmpHelper/internal/oids"
+	"github.com/jjcinaz/gosnmpHelper/snmprec"
+	"github.com/jjcinaz/gosnmpHelper/snmpwalk"
+	"net"
+	"sort"
+	"sync"
+	"sync/atomic"
+	"time"
+)
+
+// Used when a GETBULK request has no max-repetitions
+const defaultMaxRepetitions = 50
+
+// Agent is a fake SNMP agent serving a fixed set of PDUs on a loopback UDP port
+type Agent struct {
+	conn     *net.UDPConn
+	mu       sync.RWMutex
+	vars     []variable // sorted in SNMP order
+	clients  []*gosnmp.GoSNMP
+	requests int64
+	done     chan struct{}
+}
+
+// A PDU held by the agent along with its parsed OID
+type variable struct {
+	oid []uint32
+	pdu gosnmp.SnmpPDU
+}
+
+/*
+NewAgent starts an Agent serving the PDUs.  The PDU names may be given with or without the leading dot and
+the PDU values must be of the Go types gosnmp expects when sending, for example int for Integer and []byte
+or string for OctetString.
+
+NewAgent panics if the PDU names are not valid OIDs or the agent cannot listen on the loopback interface,
+as it is intended for use in tests.
+*/
+func NewAgent(pdus []gosnmp.SnmpPDU) *Agent {
+	conn, err := net.ListenUDP("udp", &net.UDPAddr{IP: net.IPv4(127, 0, 0, 1)})
+	if err != nil {
+		panic(fmt.Sprintf("snmptest: failed to listen: %v", err))
+	}
+	a := &Agent{conn: conn, done: make(chan struct{})}
+	if err = a.Set(pdus...); err != nil {
+		conn.Close()
+		panic(fmt.Sprintf("snmptest: %v", err))
+	}
+	go a.serve()
+	return a
+}
+
+/*
+NewAgentFromMap starts an Agent serving the values in the map, which is keyed by OID.  The PDU type is
+chosen from the Go type of each value:
+
+	int, int32, int64      Integer
+	uint, uint32           Gauge32
+	uint64                 Counter64
+	string, []byte         OctetString
+	net.IP                 IPAddress
+	time.Duration          TimeTicks
+	gosnmp.SnmpPDU         as given, only the Name is replaced
+
+Use a gosnmp.SnmpPDU value for other types such as Counter32 or ObjectIdentifier.  NewAgentFromMap panics
+for values of any other Go type.
+*/
+func NewAgentFromMap(values map[string]interface{}) *Agent {
+	pdus := make([]gosnmp.SnmpPDU, 0, len(values))
+	for oid, value := range values {
+		pdu, err := PDU(oid, value)
+		if err != nil {
+			panic(fmt.Sprintf("snmptest: %v", err))
+		}
+		pdus = append(pdus, pdu)
+	}
+	return NewAgent(pdus)
+}
+
+// NewAgentFromWalkFile starts an Agent serving the PDUs in a file of `snmpwalk -On` output.  See snmpwalk.Parse().
+func NewAgentFromWalkFile(filename string) (*Agent, error) {
+	pdus, err := snmpwalk.ParseFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	return NewAgent(pdus), nil
+}
+
+// NewAgentFromSnmprecFile starts an Agent serving the PDUs in an snmpsim .snmprec file.  See snmprec.Read().
+func NewAgentFromSnmprecFile(filename string) (*Agent, error) {
+	pdus, err := snmprec.ReadFile(filename)
+	if err != nil {
+		return nil, err
+	}
+	return NewAgent(pdus), nil
+}
+
+// PDU returns a PDU for the OID with the type chosen from the Go type of value.  See NewAgentFromMap().
+func PDU(oid string, value interface{}) (gosnmp.SnmpPDU, error) {
+	pdu := gosnmp.SnmpPDU{Name: oid, Value: value}
+	switch v := value.(type) {
+	case int:
+		pdu.Type = gosnmp.Integer
+	case int32:
+		pdu.Type, pdu.Value = gosnmp.Integer, int(v)
+	case int64:
+		pdu.Type, pdu.Value = gosnmp.Integer, int(v)
+	case uint:
+		pdu.Type, pdu.Value = gosnmp.Gauge32, uint32(v)
+	case uint32:
+		pdu.Type = gosnmp.Gauge32
+	case uint64:
+		pdu.Type = gosnmp.Counter64
+	case string:
+		pdu.Type, pdu.Value = gosnmp.OctetString, []byte(v)
+	case []byte:
+		pdu.Type = gosnmp.OctetString
+	case net.IP:
+		pdu.Type, pdu.Value = gosnmp.IPAddress, v.String()
+	case time.Duration:
+		pdu.Type, pdu.Value = gosnmp.TimeTicks, uint32(v/(10*time.Millisecond))
+	case gosnmp.SnmpPDU:
+		pdu = v
+		pdu.Name = oid
+	default:
+		return pdu, fmt.Errorf("%s: unsupported value type %T", oid, value)
+	}
+	return pdu, nil
+}
+
+// Set adds the PDUs to those served by the agent, replacing any with the same OID
+func (a *Agent) Set(pdus ...gosnmp.SnmpPDU) error {
+	vars := make([]variable, 0, len(pdus))
+	for _, pdu := range pdus {
+		oid, err := oids.Parse(pdu.Name)
+		if err != nil {
+			return err
+		}
+		pdu.Name = oids.Format(oid)
+		vars = append(vars, variable{oid: oid, pdu: pdu})
+	}
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	for _, v := range vars {
+		i, found := a.find(v.oid)
+		if found {
+			a.vars[i] = v
+			continue
+		}
+		a.vars = append(a.vars, variable{})
+		copy(a.vars[i+1:], a.vars[i:])
+		a.vars[i] = v
+	}
+	return nil
+}
+
+// Addr returns the UDP address the agent is listening on
+func (a *Agent) Addr() *net.UDPAddr {
+	return a.conn.LocalAddr().(*net.UDPAddr)
+}
+
+/*
+Client returns a connected SNMPv2c client for the agent with a short timeout and no retries.  Change the
+Version of the client to use SNMPv1.  The client is closed when the agent is closed.
+*/
+func (a *Agent) Client() *gosnmp.GoSNMP {
+	addr := a.Addr()
+	client := &gosnmp.GoSNMP{
+		Target:    addr.IP.String(),
+		Port:      uint16(addr.Port),
+		Community: "public",
+		Version:   gosnmp.Version2c,
+		Timeout:   time.Second,
+		Retries:   0,
+	}
+	if err := client.Connect(); err != nil {
+		panic(fmt.Sprintf("snmptest: failed to connect: %v", err))
+	}
+	a.mu.Lock()
+	a.clients = append(a.clients, client)
+	a.mu.Unlock()
+	return client
+}
+
+// Requests returns the number of requests the agent has answered
+func (a *Agent) Requests() int {
+	return int(atomic.LoadInt64(&a.requests))
+}
+
+// Close stops the agent and closes any clients returned by Client()
+func (a *Agent) Close() {
+	a.conn.Close()
+	<-a.done
+	a.mu.Lock()
+	defer a.mu.Unlock()
+	for _, client := range a.clients {
+		if client.Conn != nil {
+			client.Conn.Close()
+		}
+	}
+	a.clients = nil
+}
+
+// serve answers requests until the connection is closed
+func (a *Agent) serve() {
+	defer close(a.done)
+	buf := make([]byte, 65535)
+	for {
+		n, addr, err := a.conn.ReadFromUDP(buf)
+		if err != nil {
+			return
+		}
+		if resp := a.handle(buf[:n]); resp != nil {
+			atomic.AddInt64(&a.requests, 1)
+			a.conn.WriteToUDP(resp, addr)
+		}
+	}
+}
+
+// handle returns the encoded response to a request, or nil if there should be no response
+func (a *Agent) handle(req []byte) []byte {
+	decoder := gosnmp.GoSNMP{}
+	packet, err := decoder.SnmpDecodePacket(req)
+	if err != nil || packet.Version == gosnmp.Version3 {
+		return nil
+	}
+	resp := &gosnmp.SnmpPacket{
+		Version:   packet.Version,
+		Community: packet.Community,
+		PDUType:   gosnmp.GetResponse,
+		RequestID: packet.RequestID,
+	}
+	a.mu.RLock()
+	ok := a.answer(packet, resp)
+	a.mu.RUnlock()
+	if !ok {
+		return nil
+	}
+	if packet.Version == gosnmp.Version1 {
+		// SNMPv1 has no exception values, the whole request fails instead
+		for i, pdu := range resp.Variables {
+			if isException(pdu.Type) {
+				resp.Variables = packet.Variables
+				resp.Error, resp.ErrorIndex = gosnmp.NoSuchName, uint8(i+1)
+				break
+			}
+		}
+	}
+	out, err := resp.MarshalMsg()
+	if err != nil {
+		resp.Variables = packet.Variables
+		resp.Error, resp.ErrorIndex = gosnmp.GenErr, 0
+		if out, err = resp.MarshalMsg(); err != nil {
+			return nil
+		}
+	}
+	return out
+}
+
+// answer fills in the variables of the response to a request, returning false for unsupported requests
+func (a *Agent) answer(packet, resp *gosnmp.SnmpPacket) bool {
+	switch packet.PDUType {
+	case gosnmp.GetRequest:
+		resp.Variables = a.get(packet.Variables)
+	case gosnmp.GetNextRequest:
+		resp.Variables = a.getNext(packet.Variables)
+	case gosnmp.GetBulkRequest:
+		if packet.Version == gosnmp.Version1 {
+			return false
+		}
+		resp.Variables = a.getBulk(packet.Variables, int(packet.NonRepeaters), int(packet.MaxRepetitions))
+	case gosnmp.SetRequest:
+		resp.Variables = packet.Variables
+		resp.Error, resp.ErrorIndex = gosnmp.NotWritable, 1
+		if packet.Version == gosnmp.Version1 {
+			resp.Error = gosnmp.ReadOnly
+		}
+	default:
+		return false
+	}
+	return true
+}
+
+// get answers a GET request
+func (a *Agent) get(names []gosnmp.SnmpPDU) []gosnmp.SnmpPDU {
+	result := make([]gosnmp.SnmpPDU, len(names))
+	for i, name := range names {
+		oid, err := oids.Parse(name.Name)
+		if err != nil {
+			result[i] = gosnmp.SnmpPDU{Name: name.Name, Type: gosnmp.NoSuchObject}
+			continue
+		}
+		if j, found := a.find(oid); found {
+			result[i] = a.vars[j].pdu
+			continue
+		}
+		result[i] = gosnmp.SnmpPDU{Name: oids.Format(oid), Type: a.missingType(oid)}
+	}
+	return result
+}
+
+// getNext answers a GETNEXT request
+func (a *Agent) getNext(names []gosnmp.SnmpPDU) []gosnmp.SnmpPDU {
+	result := make([]gosnmp.SnmpPDU, len(names))
+	for i, name := range names {
+		result[i] = a.next(name.Name)
+	}
+	return result
+}
+
+// getBulk answers a GETBULK request
+func (a *Agent) getBulk(names []gosnmp.SnmpPDU, nonRepeaters, maxRepetitions int) []gosnmp.SnmpPDU {
+	if nonRepeaters > len(names) {
+		nonRepeaters = len(names)
+	}
+	if maxRepetitions <= 0 {
+		maxRepetitions = defaultMaxRepetitions
+	}
+	result := a.getNext(names[:nonRepeaters])
+	repeaters := make([]string, len(names)-nonRepeaters)
+	for i, name := range names[nonRepeaters:] {
+		repeaters[i] = name.Name
+	}
+	for r := 0; r < maxRepetitions && len(repeaters) > 0; r++ {
+		ended := true
+		for i, name := range repeaters {
+			pdu := a.next(name)
+			result = append(result, pdu)
+			repeaters[i] = pdu.Name
+			if pdu.Type != gosnmp.EndOfMibView {
+				ended = false
+			}
+		}
+		if ended {
+			break
+		}
+	}
+	return result
+}
+
+// next returns the first PDU after the OID, or an EndOfMibView PDU if there is none
+func (a *Agent) next(name string) gosnmp.SnmpPDU {
+	oid, err := oids.Parse(name)
+	if err != nil {
+		return gosnmp.SnmpPDU{Name: name, Type: gosnmp.EndOfMibView}
+	}
+	i, found := a.find(oid)
+	if found {
+		i++
+	}
+	if i >= len(a.vars) {
+		return gosnmp.SnmpPDU{Name: oids.Format(oid), Type: gosnmp.EndOfMibView}
+	}
+	return a.vars[i].pdu
+}
+
+// isException reports whether the PDU type is one of the SNMPv2 exception values
+func isException(t gosnmp.Asn1BER) bool {
+	return t == gosnmp.NoSuchObject || t == gosnmp.NoSuchInstance || t == gosnmp.EndOfMibView
+}
+
+// find returns the position of the OID in the agent's variables, or where it would be inserted
+func (a *Agent) find(oid []uint32) (int, bool) {
+	i := sort.Search(len(a.vars), func(i int) bool { return oids.Compare(a.vars[i].oid, oid) >= 0 })
+	return i, i < len(a.vars) && oids.Compare(a.vars[i].oid, oid) == 0
+}
+
+// missingType returns NoSuchInstance if the agent has other instances of the object, else NoSuchObject
+func (a *Agent) missingType(oid []uint32) gosnmp.Asn1BER {
+	if len(oid) < 2 {
+		return gosnmp.NoSuchObject
+	}
+	object := oid[:len(oid)-1]
+	i, _ := a.find(object)
+	if i < len(a.vars) && oids.HasPrefix(a.vars[i].oid, object) {
+		return gosnmp.NoSuchInstance
+	}
+	return gosnmp.NoSuchObject
+}
@@ -0,0 +1,169 @@
+package snmptest
+
+import (
+	"github.com/gosnmp/gosnmp"
+	"net"
+	"os"
+	"path/filepath"
+	"reflect"
+	"testing"
+	"time"
+)
+
+func testAgent() *Agent {
+	return NewAgentFromMap(map[string]interface{}{
+		".1.3.6.1.2.1.1.1.0":             "Test device",
+		"1.3.6.1.2.1.1.3.0":              time.Duration(12345) * 10 * time.Millisecond,
+		".1.3.6.1.2.1.2.2.1.2.1":         "lo",
+		".1.3.6.1.2.1.2.2.1.2.2":         "eth0",
+		".1.3.6.1.2.1.2.2.1.2.10":        "eth1",
+		".1.3.6.1.2.1.2.2.1.8.1":         1,
+		".1.3.6.1.2.1.2.2.1.8.2":         2,
+		".1.3.6.1.2.1.2.2.1.8.10":        1,
+		".1.3.6.1.2.1.4.20.1.1.10.0.0.1": net.IPv4(10, 0, 0, 1),
+		".1.3.6.1.2.1.31.1.1.1.6.2":      uint64(1) << 40,
+		".1.3.6.1.2.1.31.1.1.1.15.2":     uint(1000),
+		".1.3.6.1.2.1.2.2.1.10.2":        gosnmp.SnmpPDU{Type: gosnmp.Counter32, Value: uint32(4000000000)},
+	})
+}
+
+func TestAgentGet(t *testing.T) {
+	agent := testAgent()
+	defer agent.Close()
+	client := agent.Client()
+
+	result, err := client.Get([]string{".1.3.6.1.2.1.1.1.0", ".1.3.6.1.2.1.1.3.0", ".1.3.6.1.2.1.2.2.1.2.99",
+		".1.3.6.1.2.1.1.99.0", ".1.3.6.1.2.1.4.20.1.1.10.0.0.1", ".1.3.6.1.2.1.31.1.1.1.6.2"})
+	if err != nil {
+		t.Fatalf("Get() err = %v", err)
+	}
+	want := []gosnmp.SnmpPDU{
+		{Name: ".1.3.6.1.2.1.1.1.0", Type: gosnmp.OctetString, Value: []byte("Test device")},
+		{Name: ".1.3.6.1.2.1.1.3.0", Type: gosnmp.TimeTicks, Value: uint32(12345)},
+		{Name: ".1.3.6.1.2.1.2.2.1.2.99", Type: gosnmp.NoSuchInstance},
+		{Name: ".1.3.6.1.2.1.1.99.0", Type: gosnmp.NoSuchObject},
+		{Name: ".1.3.6.1.2.1.4.20.1.1.10.0.0.1", Type: gosnmp.IPAddress, Value: "10.0.0.1"},
+		{Name: ".1.3.6.1.2.1.31.1.1.1.6.2", Type: gosnmp.Counter64, Value: uint64(1) << 40},
+	}
+	if len(result.Variables) != len(want) {
+		t.Fatalf("Get() returned %d variables, want %d", len(result.Variables), len(want))
+	}
+	for i, got := range result.Variables {
+		if got.Name != want[i].Name || got.Type != want[i].Type ||
+			(want[i].Value != nil && !reflect.DeepEqual(got.Value, want[i].Value)) {
+			t.Errorf("Get() [%d] = %+v, want %+v", i, got, want[i])
+		}
+	}
+}
+
+func TestAgentWalk(t *testing.T) {
+	agent := testAgent()
+	defer agent.Close()
+	client := agent.Client()
+	want := []string{".1.3.6.1.2.1.2.2.1.2.1", ".1.3.6.1.2.1.2.2.1.2.2", ".1.3.6.1.2.1.2.2.1.2.10",
+		".1.3.6.1.2.1.2.2.1.8.1", ".1.3.6.1.2.1.2.2.1.8.2", ".1.3.6.1.2.1.2.2.1.8.10",
+		".1.3.6.1.2.1.2.2.1.10.2"}
+
+	for _, maxReps := range []uint32{0, 2} {
+		client.MaxRepetitions = maxReps
+		pdus, err := client.BulkWalkAll(".1.3.6.1.2.1.2.2.1")
+		if err != nil {
+			t.Fatalf("BulkWalkAll() err = %v", err)
+		}
+		if got := names(pdus); !reflect.DeepEqual(got, want) {
+			t.Errorf("BulkWalkAll() max-repetitions %d = %v, want %v", maxReps, got, want)
+		}
+	}
+	pdus, err := client.WalkAll(".1.3.6.1.2.1.2.2.1")
+	if err != nil {
+		t.Fatalf("WalkAll() err = %v", err)
+	}
+	if got := names(pdus); !reflect.DeepEqual(got, want) {
+		t.Errorf("WalkAll() = %v, want %v", got, want)
+	}
+	// Walking past the last OID
+	pdus, err = client.BulkWalkAll(".1.3.6.1.2.1.31")
+	if err != nil || len(pdus) != 2 {
+		t.Errorf("BulkWalkAll() at end = %v, %v", pdus, err)
+	}
+}
+
+func TestAgentVersion1(t *testing.T) {
+	agent := testAgent()
+	defer agent.Close()
+	client := agent.Client()
+	client.Version = gosnmp.Version1
+
+	result, err := client.Get([]string{".1.3.6.1.2.1.1.1.0", ".1.3.6.1.2.1.1.99.0"})
+	if err != nil {
+		t.Fatalf("Get() err = %v", err)
+	}
+	if result.Error != gosnmp.NoSuchName || result.ErrorIndex != 2 {
+		t.Errorf("Get() error = %v index %d, want noSuchName index 2", result.Error, result.ErrorIndex)
+	}
+	pdus, err := client.WalkAll(".1.3.6.1.2.1.2.2.1.8")
+	if err != nil || len(pdus) != 3 {
+		t.Errorf("WalkAll() = %v, %v", pdus, err)
+	}
+}
+
+func TestAgentSet(t *testing.T) {
+	agent := testAgent()
+	defer agent.Close()
+	client := agent.Client()
+
+	if err := agent.Set(gosnmp.SnmpPDU{Name: "1.3.6.1.2.1.1.1.0", Type: gosnmp.OctetString, Value: []byte("Changed")},
+		gosnmp.SnmpPDU{Name: ".1.3.6.1.2.1.1.5.0", Type: gosnmp.OctetString, Value: []byte("test")}); err != nil {
+		t.Fatalf("Set() err = %v", err)
+	}
+	before := agent.Requests()
+	result, err := client.Get([]string{".1.3.6.1.2.1.1.1.0", ".1.3.6.1.2.1.1.5.0"})
+	if err != nil {
+		t.Fatalf("Get() err = %v", err)
+	}
+	if got := string(result.Variables[0].Value.([]byte)); got != "Changed" {
+		t.Errorf("Get() after Set() = %q", got)
+	}
+	if got := string(result.Variables[1].Value.([]byte)); got != "test" {
+		t.Errorf("Get() of added PDU = %q", got)
+	}
+	if got := agent.Requests() - before; got != 1 {
+		t.Errorf("Requests() increased by %d, want 1", got)
+	}
+	if err = agent.Set(gosnmp.SnmpPDU{Name: "1.3.six"}); err == nil {
+		t.Errorf("Set() of invalid OID succeeded")
+	}
+	// The agent does not allow SNMP sets
+	result, err = client.Set([]gosnmp.SnmpPDU{{Name: ".1.3.6.1.2.1.1.5.0", Type: gosnmp.OctetString, Value: "x"}})
+	if err != nil || result.Error != gosnmp.NotWritable {
+		t.Errorf("client Set() = %v, %v", result, err)
+	}
+}
+
+func names(pdus []gosnmp.SnmpPDU) []string {
+	result := make([]string, len(pdus))
+	for i, pdu := range pdus {
+		result[i] = pdu.Name
+	}
+	return result
+}
+
+func TestNewAgentFromSnmprecFile(t *testing.T) {
+	filename := filepath.Join(t.TempDir(), "device.snmprec")
+	err := os.WriteFile(filename, []byte("1.3.6.1.2.1.1.1.0|4|Lab switch\n1.3.6.1.2.1.1.3.0|67|100\n"), 0o644)
+	if err != nil {
+		t.Fatal(err)
+	}
+	agent, err := NewAgentFromSnmprecFile(filename)
+	if err != nil {
+		t.Fatalf("NewAgentFromSnmprecFile() err = %v", err)
+	}
+	defer agent.Close()
+	pdus, err := agent.Client().WalkAll(".1.3.6.1.2.1.1")
+	if err != nil || len(pdus) != 2 || string(pdus[0].Value.([]byte)) != "Lab switch" || pdus[1].Value != uint32(100) {
+		t.Errorf("WalkAll() = %v, %v", pdus, err)
+	}
+	if _, err = NewAgentFromSnmprecFile(filepath.Join(t.TempDir(), "missing.snmprec")); err == nil {
+		t.Errorf("NewAgentFromSnmprecFile() of missing file succeeded")
+	}
+}
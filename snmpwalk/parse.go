@@ -0,0 +1,325 @@
+/*
+Package snmpwalk reads the text output of the net-snmp snmpwalk, snmpbulkwalk and snmpget commands, so
+captures taken from devices can be used offline.  The OIDs must be numeric, so capture with the -On option:
+
+	snmpbulkwalk -v2c -c public -On 192.168.1.1 .1.3.6.1.2.1 > router.walk
+
+The PDUs are typed and hold the same Go types gosnmp returns when decoding a response, so they can be passed
+straight to gosnmpHelper.MarshalPDUsToStruct():
+
+	pdus, err := snmpwalk.ParseFile("router.walk")
+	...
+	err = gosnmpHelper.MarshalPDUsToStructE(pdus, &info)
+*/
+package snmpwalk
+
+import (
+	"bufio"
+	"encoding/hex"
+	"errors"
+	"fmt"
+	"github.com/gosnmp/gosnmp"
+	"io"
+	"math"
+	"net"
+	"os"
+	"regexp"
+	"strconv"
+	"strings"
+)
+
+// ErrSyntax is returned when a line is not in the expected format
+var ErrSyntax = errors.New("invalid snmpwalk output")
+
+// ErrUnsupportedType is returned when a line has a value type which cannot be converted to a PDU
+var ErrUnsupportedType = errors.New("unsupported snmpwalk value type")
+
+// A line starting a new value, such as ".1.3.6.1.2.1.1.1.0 = STRING: ..."
+var startRx = regexp.MustCompile(`^\.?\d+(?:\.\d+)* = `)
+
+// Values net-snmp prints in place of a type and value
+var exceptions = map[string]gosnmp.Asn1BER{
+	"No Such Object available on this agent at this OID":                           gosnmp.NoSuchObject,
+	"No Such Instance currently exists at this OID":                                gosnmp.NoSuchInstance,
+	"No more variables left in this MIB View (It is past the end of the MIB tree)": gosnmp.EndOfMibView,
+	"NULL": gosnmp.Null,
+}
+
+// ParseFile reads the snmpwalk output in the named file.  See Parse().
+func ParseFile(filename string) ([]gosnmp.SnmpPDU, error) {
+	f, err := os.Open(filename)
+	if err != nil {
+		return nil, err
+	}
+	defer f.Close()
+	pdus, err := Parse(f)
+	if err != nil {
+		return pdus, fmt.Errorf("%s: %w", filename, err)
+	}
+	return pdus, nil
+}
+
+/*
+Parse reads snmpwalk output such as:
+
+	.1.3.6.1.2.1.1.1.0 = STRING: "Linux router 5.10.0"
+	.1.3.6.1.2.1.1.3.0 = Timeticks: (12345) 0:02:03.45
+	.1.3.6.1.2.1.2.2.1.6.2 = Hex-STRING: 00 11 22 33 44 55
+	.1.3.6.1.2.1.2.2.1.8.1 = INTEGER: up(1)
+
+Values which continue over several lines, such as strings containing newlines and long Hex-STRINGs, are
+joined back together.  Blank lines are skipped.  The value types are converted as follows:
+
+	STRING, Hex-STRING, BITS           OctetString, []byte
+	INTEGER                            Integer, int
+	Counter32, Gauge32                 Counter32 and Gauge32, uint
+	Timeticks                          TimeTicks, uint32
+	UInteger32                         Uinteger32, uint32
+	Counter64                          Counter64, uint64
+	IpAddress, Network Address         IPAddress, string
+	OID                                ObjectIdentifier, string
+	Opaque: Float, Opaque: Double      OpaqueFloat, float32 and OpaqueDouble, float64
+	Opaque                             Opaque, []byte
+	"" (an empty string)               OctetString, []byte{}
+	NULL                               Null, nil
+
+along with the messages for noSuchObject, noSuchInstance and endOfMibView.  Values printed by net-snmp as
+"Wrong Type (should be ...)" are read using their actual type.  Trailing units, such as in
+"INTEGER: 1500 octets", are ignored.
+
+Any other line results in an error wrapping ErrSyntax or ErrUnsupportedType, which includes the line number.
+The PDUs before the line are returned with the error.
+*/
+func Parse(r io.Reader) ([]gosnmp.SnmpPDU, error) {
+	var (
+		pdus    []gosnmp.SnmpPDU
+		entry   strings.Builder
+		start   int // line number of the start of entry
+		lineNum int
+	)
+	flush := func() error {
+		if entry.Len() == 0 {
+			return nil
+		}
+		pdu, err := ParseLine(entry.String())
+		entry.Reset()
+		if err != nil {
+			return fmt.Errorf("line %d: %w", start, err)
+		}
+		pdus = append(pdus, pdu)
+		return nil
+	}
+	scanner := bufio.NewScanner(r)
+	scanner.Buffer(make([]byte, 0, 64*1024), 16*1024*1024)
+	for scanner.Scan() {
+		lineNum++
+		line := strings.TrimRight(scanner.Text(), "\r")
+		switch {
+		case startRx.MatchString(line):
+			if err := flush(); err != nil {
+				return pdus, err
+			}
+			start = lineNum
+			entry.WriteString(line)
+		case entry.Len() > 0 && continues(entry.String()):
+			// Continuation of a value over several lines
+			entry.WriteByte('\n')
+			entry.WriteString(line)
+		case len(strings.TrimSpace(line)) > 0:
+			if err := flush(); err != nil {
+				return pdus, err
+			}
+			return pdus, fmt.Errorf("line %d: %w: %q", lineNum, ErrSyntax, line)
+		}
+	}
+	if err := scanner.Err(); err != nil {
+		return pdus, err
+	}
+	return pdus, flush()
+}
+
+// ParseLine converts a single value of snmpwalk output, which may span several lines, to a PDU.  See Parse().
+func ParseLine(line string) (gosnmp.SnmpPDU, error) {
+	var pdu gosnmp.SnmpPDU
+	name, rest, ok := strings.Cut(strings.TrimRight(line, " \t\r\n"), " = ")
+	if !ok || !startRx.MatchString(name+" = ") {
+		return pdu, fmt.Errorf("%w: %q", ErrSyntax, line)
+	}
+	pdu.Name = name
+	if !strings.HasPrefix(name, ".") {
+		pdu.Name = "." + name
+	}
+	if t, ok := exceptions[rest]; ok {
+		pdu.Type = t
+		return pdu, nil
+	}
+	if rest == `""` {
+		pdu.Type, pdu.Value = gosnmp.OctetString, []byte{}
+		return pdu, nil
+	}
+	if strings.HasPrefix(rest, "Wrong Type (should be ") {
+		if _, actual, ok := strings.Cut(rest, "): "); ok {
+			rest = actual
+		}
+	}
+	typ, value, ok := strings.Cut(rest, ":")
+	if !ok {
+		return pdu, fmt.Errorf("%w: missing type in %q", ErrSyntax, line)
+	}
+	value = strings.TrimSpace(value)
+	var err error
+	switch typ {
+	case "STRING":
+		pdu.Type, pdu.Value = gosnmp.OctetString, parseString(value)
+	case "Hex-STRING":
+		pdu.Type = gosnmp.OctetString
+		pdu.Value, err = parseHex(value)
+	case "BITS":
+		// The hex bytes are followed by the names of the set bits, such as "BITS: 80 00 first(0)"
+		fields := strings.Fields(value)
+		for len(fields) > 0 && strings.Contains(fields[len(fields)-1], "(") {
+			fields = fields[:len(fields)-1]
+		}
+		pdu.Type = gosnmp.OctetString
+		pdu.Value, err = parseHex(strings.Join(fields, " "))
+	case "INTEGER":
+		pdu.Type = gosnmp.Integer
+		if i := strings.LastIndexByte(value, '('); i >= 0 && strings.HasSuffix(value, ")") {
+			// enumerated, such as "up(1)"
+			value = value[i+1 : len(value)-1]
+		}
+		var n int64
+		n, err = strconv.ParseInt(firstField(value), 10, 64)
+		pdu.Value = int(n)
+		if err == nil && (n < math.MinInt32 || n > math.MaxInt32) {
+			err = strconv.ErrRange
+		}
+	case "Counter32", "Gauge32":
+		pdu.Type = gosnmp.Counter32
+		if typ == "Gauge32" {
+			pdu.Type = gosnmp.Gauge32
+		}
+		var n uint64
+		n, err = strconv.ParseUint(firstField(value), 10, 32)
+		pdu.Value = uint(n)
+	case "Timeticks", "UInteger32":
+		pdu.Type = gosnmp.TimeTicks
+		if typ == "UInteger32" {
+			pdu.Type = gosnmp.Uinteger32
+		}
+		if strings.HasPrefix(value, "(") {
+			// Such as "(12345) 0:02:03.45"
+			value, _, _ = strings.Cut(value[1:], ")")
+		}
+		var n uint64
+		n, err = strconv.ParseUint(firstField(value), 10, 32)
+		pdu.Value = uint32(n)
+	case "Counter64":
+		pdu.Type = gosnmp.Counter64
+		pdu.Value, err = strconv.ParseUint(firstField(value), 10, 64)
+	case "IpAddress":
+		pdu.Type = gosnmp.IPAddress
+		ip := net.ParseIP(value)
+		if ip == nil {
+			err = ErrSyntax
+		} else {
+			pdu.Value = ip.String()
+		}
+	case "Network Address":
+		// Hex bytes separated by colons, such as "0A:00:00:01"
+		pdu.Type = gosnmp.IPAddress
+		var b []byte
+		if b, err = parseHex(strings.ReplaceAll(value, ":", " ")); err == nil && len(b) != net.IPv4len {
+			err = ErrSyntax
+		} else if err == nil {
+			pdu.Value = net.IP(b).String()
+		}
+	case "OID":
+		pdu.Type, pdu.Value = gosnmp.ObjectIdentifier, value
+		if !startRx.MatchString(value + " = ") {
+			err = ErrSyntax
+		} else if !strings.HasPrefix(value, ".") {
+			pdu.Value = "." + value
+		}
+	case "Opaque":
+		pdu, err = parseOpaque(pdu, value)
+	default:
+		return pdu, fmt.Errorf("%w: %q", ErrUnsupportedType, typ)
+	}
+	if err != nil {
+		return pdu, fmt.Errorf("%w: invalid %s value %q", ErrSyntax, typ, value)
+	}
+	return pdu, nil
+}
+
+// continues reports whether the value started in entry may carry on over the next line
+func continues(entry string) bool {
+	_, rest, _ := strings.Cut(entry, " = ")
+	typ, value, _ := strings.Cut(rest, ": ")
+	switch typ {
+	case "Hex-STRING", "BITS", "Opaque":
+		return true
+	case "STRING":
+		// Until the closing quote, which is not escaped
+		if !strings.HasPrefix(value, `"`) {
+			return false
+		}
+		value = strings.TrimRight(value, " \t")
+		if len(value) < 2 || !strings.HasSuffix(value, `"`) {
+			return true
+		}
+		escapes := len(value) - 1 - len(strings.TrimRight(value[:len(value)-1], `\`))
+		return escapes%2 == 1
+	}
+	return false
+}
+
+// parseOpaque converts the value of an Opaque, which is either "Float: 1.5", "Double: 1.5" or hex bytes
+func parseOpaque(pdu gosnmp.SnmpPDU, value string) (gosnmp.SnmpPDU, error) {
+	var err error
+	kind, number, _ := strings.Cut(value, ":")
+	switch kind {
+	case "Float":
+		var f float64
+		f, err = strconv.ParseFloat(strings.TrimSpace(number), 32)
+		pdu.Type, pdu.Value = gosnmp.OpaqueFloat, float32(f)
+	case "Double":
+		var f float64
+		f, err = strconv.ParseFloat(strings.TrimSpace(number), 64)
+		pdu.Type, pdu.Value = gosnmp.OpaqueDouble, f
+	default:
+		pdu.Type = gosnmp.Opaque
+		pdu.Value, err = parseHex(value)
+	}
+	return pdu, err
+}
+
+// parseString returns the bytes of a STRING value, which is quoted unless snmpwalk was run with -Oq or similar
+func parseString(value string) []byte {
+	if len(value) < 2 || value[0] != '"' || value[len(value)-1] != '"' {
+		return []byte(value)
+	}
+	value = value[1 : len(value)-1]
+	result := make([]byte, 0, len(value))
+	for i := 0; i < len(value); i++ {
+		// net-snmp escapes quotes and backslashes within quoted strings
+		if value[i] == '\\' && i+1 < len(value) && (value[i+1] == '"' || value[i+1] == '\\') {
+			i++
+		}
+		result = append(result, value[i])
+	}
+	return result
+}
+
+// parseHex decodes hex bytes separated by white space, which may include newlines
+func parseHex(value string) ([]byte, error) {
+	return hex.DecodeString(strings.Join(strings.Fields(value), ""))
+}
+
+// firstField returns value up to the first white space, dropping any units
+func firstField(value string) string {
+	if fields := strings.Fields(value); len(fields) > 0 {
+		return fields[0]
+	}
+	return value
+}
@@ -0,0 +1,132 @@
+package snmpwalk
+
+import (
+	"errors"
+	"github.com/gosnmp/gosnmp"
+	"reflect"
+	"strings"
+	"testing"
+)
+
+func TestParseLine(t *testing.T) {
+	tests := []struct {
+		name    string
+		line    string
+		want    gosnmp.SnmpPDU
+		wantErr error
+	}{
+		{name: "String", line: `.1.3.6.1.2.1.1.1.0 = STRING: "Linux router"`,
+			want: gosnmp.SnmpPDU{Name: ".1.3.6.1.2.1.1.1.0", Type: gosnmp.OctetString, Value: []byte("Linux router")}},
+		{name: "Escaped string", line: `.1.3.6.1.2.1.1.1.0 = STRING: "say \"hi\" C:\\"`,
+			want: gosnmp.SnmpPDU{Name: ".1.3.6.1.2.1.1.1.0", Type: gosnmp.OctetString, Value: []byte(`say "hi" C:\`)}},
+		{name: "Unquoted string", line: `.1.3.6.1.2.1.2.2.1.2.1 = STRING: lo`,
+			want: gosnmp.SnmpPDU{Name: ".1.3.6.1.2.1.2.2.1.2.1", Type: gosnmp.OctetString, Value: []byte("lo")}},
+		{name: "Empty string", line: `.1.3.6.1.2.1.2.2.1.6.1 = ""`,
+			want: gosnmp.SnmpPDU{Name: ".1.3.6.1.2.1.2.2.1.6.1", Type: gosnmp.OctetString, Value: []byte{}}},
+		{name: "No leading dot", line: `1.3.6.1.2.1.1.5.0 = STRING: "router"`,
+			want: gosnmp.SnmpPDU{Name: ".1.3.6.1.2.1.1.5.0", Type: gosnmp.OctetString, Value: []byte("router")}},
+		{name: "Hex string", line: `.1.3.6.1.2.1.2.2.1.6.2 = Hex-STRING: 00 11 22 AA BB CC `,
+			want: gosnmp.SnmpPDU{Name: ".1.3.6.1.2.1.2.2.1.6.2", Type: gosnmp.OctetString, Value: []byte{0, 0x11, 0x22, 0xaa, 0xbb, 0xcc}}},
+		{name: "Bits", line: `.1.3.6.1.2.1.10.166.1.1.0 = BITS: 80 40 first(0) tenth(9)`,
+			want: gosnmp.SnmpPDU{Name: ".1.3.6.1.2.1.10.166.1.1.0", Type: gosnmp.OctetString, Value: []byte{0x80, 0x40}}},
+		{name: "Integer", line: `.1.3.6.1.2.1.1.7.0 = INTEGER: 72`,
+			want: gosnmp.SnmpPDU{Name: ".1.3.6.1.2.1.1.7.0", Type: gosnmp.Integer, Value: 72}},
+		{name: "Negative integer", line: `.1.3.6.1.4.1.9.9.91.1.1.1.1.4.1 = INTEGER: -42`,
+			want: gosnmp.SnmpPDU{Name: ".1.3.6.1.4.1.9.9.91.1.1.1.1.4.1", Type: gosnmp.Integer, Value: -42}},
+		{name: "Enumerated integer", line: `.1.3.6.1.2.1.2.2.1.8.1 = INTEGER: up(1)`,
+			want: gosnmp.SnmpPDU{Name: ".1.3.6.1.2.1.2.2.1.8.1", Type: gosnmp.Integer, Value: 1}},
+		{name: "Integer with units", line: `.1.3.6.1.2.1.2.2.1.4.2 = INTEGER: 1500 octets`,
+			want: gosnmp.SnmpPDU{Name: ".1.3.6.1.2.1.2.2.1.4.2", Type: gosnmp.Integer, Value: 1500}},
+		{name: "Counter32", line: `.1.3.6.1.2.1.2.2.1.10.2 = Counter32: 4000000000`,
+			want: gosnmp.SnmpPDU{Name: ".1.3.6.1.2.1.2.2.1.10.2", Type: gosnmp.Counter32, Value: uint(4000000000)}},
+		{name: "Gauge32", line: `.1.3.6.1.2.1.2.2.1.5.2 = Gauge32: 1000000000`,
+			want: gosnmp.SnmpPDU{Name: ".1.3.6.1.2.1.2.2.1.5.2", Type: gosnmp.Gauge32, Value: uint(1000000000)}},
+		{name: "Timeticks", line: `.1.3.6.1.2.1.1.3.0 = Timeticks: (12345) 0:02:03.45`,
+			want: gosnmp.SnmpPDU{Name: ".1.3.6.1.2.1.1.3.0", Type: gosnmp.TimeTicks, Value: uint32(12345)}},
+		{name: "Numeric timeticks", line: `.1.3.6.1.2.1.1.3.0 = Timeticks: 12345`,
+			want: gosnmp.SnmpPDU{Name: ".1.3.6.1.2.1.1.3.0", Type: gosnmp.TimeTicks, Value: uint32(12345)}},
+		{name: "UInteger32", line: `.1.3.6.1.4.1.2021.4.5.0 = UInteger32: 7`,
+			want: gosnmp.SnmpPDU{Name: ".1.3.6.1.4.1.2021.4.5.0", Type: gosnmp.Uinteger32, Value: uint32(7)}},
+		{name: "Counter64", line: `.1.3.6.1.2.1.31.1.1.1.6.2 = Counter64: 98765432109876`,
+			want: gosnmp.SnmpPDU{Name: ".1.3.6.1.2.1.31.1.1.1.6.2", Type: gosnmp.Counter64, Value: uint64(98765432109876)}},
+		{name: "IpAddress", line: `.1.3.6.1.2.1.4.20.1.1.10.0.0.1 = IpAddress: 10.0.0.1`,
+			want: gosnmp.SnmpPDU{Name: ".1.3.6.1.2.1.4.20.1.1.10.0.0.1", Type: gosnmp.IPAddress, Value: "10.0.0.1"}},
+		{name: "Network Address", line: `.1.3.6.1.2.1.4.22.1.3.2.10.0.0.1 = Network Address: 0A:00:00:01`,
+			want: gosnmp.SnmpPDU{Name: ".1.3.6.1.2.1.4.22.1.3.2.10.0.0.1", Type: gosnmp.IPAddress, Value: "10.0.0.1"}},
+		{name: "OID", line: `.1.3.6.1.2.1.1.2.0 = OID: .1.3.6.1.4.1.8072.3.2.10`,
+			want: gosnmp.SnmpPDU{Name: ".1.3.6.1.2.1.1.2.0", Type: gosnmp.ObjectIdentifier, Value: ".1.3.6.1.4.1.8072.3.2.10"}},
+		{name: "Opaque float", line: `.1.3.6.1.4.1.2021.10.1.6.1 = Opaque: Float: 0.150000`,
+			want: gosnmp.SnmpPDU{Name: ".1.3.6.1.4.1.2021.10.1.6.1", Type: gosnmp.OpaqueFloat, Value: float32(0.15)}},
+		{name: "Opaque double", line: `.1.3.6.1.4.1.2021.10.1.6.2 = Opaque: Double: 2.5`,
+			want: gosnmp.SnmpPDU{Name: ".1.3.6.1.4.1.2021.10.1.6.2", Type: gosnmp.OpaqueDouble, Value: 2.5}},
+		{name: "Wrong type", line: `.1.3.6.1.2.1.2.2.1.5.3 = Wrong Type (should be Gauge32 or Unsigned32): INTEGER: 100`,
+			want: gosnmp.SnmpPDU{Name: ".1.3.6.1.2.1.2.2.1.5.3", Type: gosnmp.Integer, Value: 100}},
+		{name: "No such object", line: `.1.3.6.1.2.1.1.99.0 = No Such Object available on this agent at this OID`,
+			want: gosnmp.SnmpPDU{Name: ".1.3.6.1.2.1.1.99.0", Type: gosnmp.NoSuchObject}},
+		{name: "No such instance", line: `.1.3.6.1.2.1.1.1.1 = No Such Instance currently exists at this OID`,
+			want: gosnmp.SnmpPDU{Name: ".1.3.6.1.2.1.1.1.1", Type: gosnmp.NoSuchInstance}},
+		{name: "End of MIB view", line: `.1.3.6.1.6.3.16.2.2.1 = No more variables left in this MIB View (It is past the end of the MIB tree)`,
+			want: gosnmp.SnmpPDU{Name: ".1.3.6.1.6.3.16.2.2.1", Type: gosnmp.EndOfMibView}},
+		{name: "Symbolic name", line: `SNMPv2-MIB::sysDescr.0 = STRING: "Linux router"`, wantErr: ErrSyntax},
+		{name: "Unsupported type", line: `.1.3.6.1.2.1.1.1.0 = Unknown: 1`, wantErr: ErrUnsupportedType},
+		{name: "Bad integer", line: `.1.3.6.1.2.1.1.7.0 = INTEGER: seventy`, wantErr: ErrSyntax},
+		{name: "Integer overflow", line: `.1.3.6.1.2.1.1.7.0 = INTEGER: 2147483648`, wantErr: ErrSyntax},
+		{name: "Bad hex", line: `.1.3.6.1.2.1.2.2.1.6.2 = Hex-STRING: 00 1`, wantErr: ErrSyntax},
+		{name: "Bad IpAddress", line: `.1.3.6.1.2.1.4.20.1.1.10.0.0.1 = IpAddress: 10.0.0`, wantErr: ErrSyntax},
+		{name: "Missing type", line: `.1.3.6.1.2.1.1.1.0 = hello`, wantErr: ErrSyntax},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			got, err := ParseLine(tt.line)
+			if tt.wantErr != nil {
+				if !errors.Is(err, tt.wantErr) {
+					t.Errorf("ParseLine() err = %v, want %v", err, tt.wantErr)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("ParseLine() err = %v", err)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("ParseLine() = %#v, want %#v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestParse(t *testing.T) {
+	walk := `.1.3.6.1.2.1.1.1.0 = STRING: "Linux router
+kernel 5.10.0 \"lts\""
+.1.3.6.1.2.1.1.3.0 = Timeticks: (7654321) 21:15:43.21
+
+.1.3.6.1.2.1.1.9.1.3.1 = STRING: "ends with a quote\""
+.1.3.6.1.2.1.2.2.1.6.2 = Hex-STRING: 00 01 02 03 04 05 06 07 08 09 0A 0B 0C 0D 0E 0F
+10 11 12 13
+.1.3.6.1.2.1.2.2.1.8.2 = INTEGER: up(1)` + "\r\n"
+	want := []gosnmp.SnmpPDU{
+		{Name: ".1.3.6.1.2.1.1.1.0", Type: gosnmp.OctetString, Value: []byte("Linux router\nkernel 5.10.0 \"lts\"")},
+		{Name: ".1.3.6.1.2.1.1.3.0", Type: gosnmp.TimeTicks, Value: uint32(7654321)},
+		{Name: ".1.3.6.1.2.1.1.9.1.3.1", Type: gosnmp.OctetString, Value: []byte(`ends with a quote"`)},
+		{Name: ".1.3.6.1.2.1.2.2.1.6.2", Type: gosnmp.OctetString,
+			Value: []byte{0, 1, 2, 3, 4, 5, 6, 7, 8, 9, 10, 11, 12, 13, 14, 15, 16, 17, 18, 19}},
+		{Name: ".1.3.6.1.2.1.2.2.1.8.2", Type: gosnmp.Integer, Value: 1},
+	}
+	got, err := Parse(strings.NewReader(walk))
+	if err != nil {
+		t.Fatalf("Parse() err = %v", err)
+	}
+	if !reflect.DeepEqual(got, want) {
+		t.Errorf("Parse() = %v, want %v", got, want)
+	}
+
+	// Junk after a value which can't continue on the next line
+	got, err = Parse(strings.NewReader(".1.3.6.1.2.1.1.7.0 = INTEGER: 72\nTimeout: No Response from 10.0.0.1\n"))
+	if !errors.Is(err, ErrSyntax) || !strings.Contains(err.Error(), "line 2") || len(got) != 1 {
+		t.Errorf("Parse() with junk = %v, %v", got, err)
+	}
+	// Errors report the line the value started on
+	_, err = Parse(strings.NewReader(".1.3.6.1.2.1.1.7.0 = INTEGER: 72\n.1.3.6.1.2.1.2.2.1.6.2 = Hex-STRING: 00\nZZ\n"))
+	if !errors.Is(err, ErrSyntax) || !strings.Contains(err.Error(), "line 2") {
+		t.Errorf("Parse() with bad hex err = %v", err)
+	}
+}
@@ -1,10 +1,12 @@
 package gosnmpHelper
 
 import (
+	"errors"
 	"fmt"
 	"github.com/gosnmp/gosnmp"
+	"math"
 	"reflect"
-	"regexp"
+	"strconv"
 	"strings"
 )
 
@@ -46,38 +48,19 @@ Had the call been like:
 
 Then the returned slice would look like:
     a = []string{".1.3.6.1.2.1.1.1.0", ".1.3.6.1.2.1.1.2.0", ".1.3.6.1.2.1.2.1.0"}
+
+Members whose tags can't be used, such as a symbolic name from a module not registered, are skipped.  Use
+NewCodec() and Codec.Oids() to have these reported as errors instead.
 */
 func GetOidsFromStructTags(source interface{}, getNested bool) []string {
 	if source == nil {
 		return []string{}
 	}
-	isnil := false
-	srcT := reflect.TypeOf(source)
-	if srcT.Kind() == reflect.Ptr {
-		srcT = srcT.Elem()
-		isnil = reflect.ValueOf(source).IsNil()
+	c, err := legacyCodec(reflect.TypeOf(source))
+	if err != nil {
+		panic(err)
 	}
-	srcV := reflect.Indirect(reflect.ValueOf(source))
-	numfields := srcT.NumField()
-	result := make([]string, 0, numfields)
-	for i := 0; i < numfields; i++ {
-		fInfo := srcT.Field(i)
-		if oid := fInfo.Tag.Get("oid"); len(oid) > 0 {
-			result = append(result, oid)
-		}
-		if !isnil && getNested {
-			field := srcV.Field(i)
-			switch field.Kind() {
-			case reflect.Ptr:
-				if field.Elem().Kind() == reflect.Struct {
-					result = append(result, GetOidsFromStructTags(field.Interface(), true)...)
-				}
-			case reflect.Struct:
-				result = append(result, GetOidsFromStructTags(field.Interface(), true)...)
-			}
-		}
-	}
-	return result
+	return c.Oids(source, getNested)
 }
 
 /*
@@ -85,9 +68,40 @@ Helper function which processes a slice of PDUs into a struct.
 See help text on MarshalPDUToStruct() for details.
 */
 func MarshalPDUsToStruct(pdus []gosnmp.SnmpPDU, dest interface{}) {
+	if dest == nil || len(pdus) == 0 {
+		return
+	}
+	c := legacyDestCodec(dest)
+	rows := rowIndexes{}
+	for _, pdu := range pdus {
+		if _, err := c.marshalRows(pdu, dest, rows); err != nil && !errors.Is(err, ErrConversion) {
+			panic(err)
+		}
+	}
+}
+
+/*
+Helper function which processes a slice of PDUs into a struct, returning errors instead of panicking.
+Processing continues past PDUs which fail so one bad value doesn't prevent the rest of the struct from
+being filled in.  The errors for all failed PDUs are joined into the returned error.
+See help text on MarshalPDUToStructE() for details.
+*/
+func MarshalPDUsToStructE(pdus []gosnmp.SnmpPDU, dest interface{}) error {
+	if len(pdus) == 0 {
+		return nil
+	}
+	c, err := destCodec(dest)
+	if err != nil {
+		return err
+	}
+	var errs []error
+	rows := rowIndexes{}
 	for _, pdu := range pdus {
-		MarshalPDUToStruct(pdu, dest)
+		if _, err := c.marshalRows(pdu, dest, rows); err != nil {
+			errs = append(errs, err)
+		}
 	}
+	return errors.Join(errs...)
 }
 
 /*
@@ -142,6 +156,26 @@ For example, the following SysInfo1 and SysInfo2 are allowed:
 		Intfs       *SysIntfs
 	}
 
+Members of type net.IP, netip.Addr and netip.Prefix are filled from IPAddress PDUs and from OctetStrings
+holding an InetAddress (4 or 16 bytes) or an address in text form.  See GetAsIP() for details.
+
+Members of type time.Duration are filled from TimeTicks such as sysUpTime, and members of type time.Time from
+DateAndTime OctetStrings such as hrSystemDate.  See GetAsDuration() and GetAsDateAndTime() for details.
+
+Members of type net.HardwareAddr and [6]byte are filled from MAC addresses, either as the raw 6 bytes or in
+any of the text formats accepted by NormalizeMac().
+
+Enumerated INTEGERs can be held by a type implementing Enum, such as IfOperStatus, or described with an enum
+tag.  A string member with an enum tag receives the name of the value, or the number for values the tag
+doesn't name, and an integer member accepts names as well as numbers:
+
+	IfAdminStatus map[string]string `oidx:"IF-MIB::ifAdminStatus.(\\d+)" enum:"up=1,down=2,testing=3"`
+
+A string member with a hint tag receives the value formatted by the DISPLAY-HINT of its textual convention,
+rather than the raw octets.  See FormatDisplayHint() for details:
+
+	IfPhysAddress map[string]string `oidx:"IF-MIB::ifPhysAddress.(\\d+)" hint:"1x:"`
+
 The following is not allowed and no OID match will be made for the field SysName:
 
 	type SysInfo1 struct {
@@ -153,7 +187,8 @@ The following is not allowed and no OID match will be made for the field SysName
 
 Struct tags can be a simple string or a regular expressing.  In the simple string case:
 	ex: `oid:".1.3.6.1.2.1.1.1.0"`
-The OID value matching the tag will cause the PDU value to be copied into the struct member.
+The OID value matching the tag will cause the PDU value to be copied into the struct member.  The leading
+dot is optional in both the tag and the PDU name, so "1.3.6.1.2.1.1.1.0" matches ".1.3.6.1.2.1.1.1.0".
 In the case of a struct member which is of type map[string]<type>, the map key value can be
 derrived from a regular expression with a capture group.  For example:
 
@@ -165,137 +200,602 @@ within a struct tag, the internal regx string must be doubly escaped; hence all
 An alternate tag format is allowed where no internal double quotes are used as follows:
 
 		IfDesc map[string]string `oidx:\.1\.3\.6\.1\.2\.1\.2\.2\.1\.2\.(\d+)`
+
+Tables with compound indexes can use multiple capture groups.  For a map[string]<type>, the captured values
+are joined with "." to form the key.  The map may instead be keyed by a struct, in which case named capture
+groups are stored into the key member of the same name (ignoring case) and unnamed groups fill the exported
+key members in order.  Members may be strings or integers.  Or the map may be nested, with each captured
+value selecting the next level of map:
+
+	type FdbKey struct {
+		Vlan int
+		Mac  string
+	}
+	FdbPort map[FdbKey]int `oidx:"\\.1\\.3\\.6\\.1\\.2\\.1\\.17\\.7\\.1\\.2\\.2\\.1\\.2\\.(?P<vlan>\\d+)\\.(?P<mac>[\\d.]+)"`
+
+or
+
+	FdbPort map[string]map[string]int `oidx:\.1\.3\.6\.1\.2\.1\.17\.7\.1\.2\.2\.1\.2\.(\d+)\.([\d.]+)`
+
+A returned value with OID .1.3.6.1.2.1.17.7.1.2.2.1.2.10.0.17.34.51.68.85 is stored in
+FdbPort[FdbKey{10, "0.17.34.51.68.85"}] in the first case and in FdbPort["10"]["0.17.34.51.68.85"] in the second.
+
+Whole table rows can be assembled with an oidtable tag on a member of type map[string]Row or []Row, where the
+tag value is the OID of the table entry and Row is a struct whose members carry oidcol tags giving the column
+sub-identifier.  A Row member tagged oidcol:"index" receives the instance index, which may be a string or an
+integer type.  For example:
+
+	type IfRow struct {
+		IfIndex      int    `oidcol:"index"`
+		IfDesc       string `oidcol:"2"`
+		IfOperStatus int    `oidcol:"8"`
+	}
+	var s struct {
+		Interfaces map[string]IfRow `oidtable:".1.3.6.1.2.1.2.2.1"`
+	}
+
+In this example, a returned value with OID .1.3.6.1.2.1.2.2.1.8.6 will set IfOperStatus in the row with key="6".
+Compound indexes are kept intact as the key, so .1.3.6.1.2.1.4.22.1.2.3.10.0.0.1 in an ipNetToMediaEntry
+table lands in the row with key "3.10.0.0.1".  An index which an integer index member can't hold, such as a
+compound index, is reported as ErrConversion.  For []Row members, the Row type must have an index member so that
+existing rows can be found, and rows whose index can't be stored are not added.
+
+This function panics if dest is not a pointer to a struct, or if a PDU matches a member of an unsupported type.
+PDU values which can't be converted to the member type are silently stored as 0, and members whose tags
+can't be used, such as a symbolic name from a module not registered or an invalid enum tag, never match.
+Use MarshalPDUToStructE() or a Codec to have these reported as errors instead.
 */
 func MarshalPDUToStruct(pdu gosnmp.SnmpPDU, dest interface{}) bool {
 	if dest == nil {
 		return false
 	}
-	if reflect.TypeOf(dest).Kind() != reflect.Ptr {
-		panic(fmt.Errorf("dest must be a pointer to a struct"))
+	found, err := legacyDestCodec(dest).Marshal(pdu, dest)
+	if err != nil && !errors.Is(err, ErrConversion) {
+		panic(err)
 	}
-	if reflect.TypeOf(dest).Elem().Kind() != reflect.Struct {
-		panic(fmt.Errorf("dest must be a pointer to a struct"))
+	return found
+}
+
+// legacyDestCodec returns the Codec from legacyCodec() for dest, panicking with ErrNotPointer if dest is not a
+// non-nil pointer to a struct
+func legacyDestCodec(dest interface{}) *Codec {
+	if !isStructPtr(dest) {
+		panic(ErrNotPointer)
 	}
-	destType := reflect.TypeOf(dest).Elem()
-	destV := reflect.ValueOf(dest)
-	// Walk through fields of struct and check for oid tag
-	for i := 0; i < destType.NumField(); i++ {
-		tag := destType.Field(i).Tag
-		v := destV.Elem().Field(i)
-		switch v.Kind() {
-		case reflect.Map:
-			if m, found := processOidTag(tag, pdu.Name); found {
-				assignToMap(m[1], pdu, v)
-				return true
-			}
-		case reflect.Uint, reflect.Uint32, reflect.Uint64:
-			if _, found := processOidTag(tag, pdu.Name); found {
-				v.SetUint(GetAsUint64(pdu))
-				return true
-			}
-		case reflect.Int, reflect.Int32, reflect.Int64:
-			if _, found := processOidTag(tag, pdu.Name); found {
-				v.SetInt(GetAsInt64(pdu))
-				return true
+	c, _ := legacyCodec(reflect.TypeOf(dest))
+	return c
+}
+
+/*
+Same as MarshalPDUToStruct() but rather than panicking, an error is returned.  The error will be ErrNotPointer
+if dest is not a non-nil pointer to a struct.  Otherwise, if the PDU matched a struct member but could not be
+stored, the error is a *FieldError giving the path to the member, such as "Intfs.IfOperStatus[6]", and
+wrapping either ErrUnsupportedField or ErrConversion.  Use errors.Is() to test for these.
+
+An ErrConversion for a numeric member also wraps the error from the matching checked getter, such as
+ErrOverflow or ErrParse.  In the case of ErrConversion, found is true and the member is still set to the
+value the GetAs functions produce (usually 0).  MarshalPDUToStruct() ignores conversion errors entirely.
+*/
+func MarshalPDUToStructE(pdu gosnmp.SnmpPDU, dest interface{}) (found bool, err error) {
+	c, err := destCodec(dest)
+	if err != nil {
+		return false, err
+	}
+	return c.Marshal(pdu, dest)
+}
+
+// destCodec returns the Codec for dest, or ErrNotPointer if dest is not a non-nil pointer to a struct
+func destCodec(dest interface{}) (*Codec, error) {
+	if !isStructPtr(dest) {
+		return nil, ErrNotPointer
+	}
+	return NewCodec(reflect.TypeOf(dest))
+}
+
+// isStructPtr reports whether dest is a non-nil pointer to a struct
+func isStructPtr(dest interface{}) bool {
+	destT := reflect.TypeOf(dest)
+	return destT != nil && destT.Kind() == reflect.Ptr && destT.Elem().Kind() == reflect.Struct && !reflect.ValueOf(dest).IsNil()
+}
+
+// joinPath appends a struct member name to the path of its parent
+func joinPath(path string, name string) string {
+	if len(path) == 0 {
+		return name
+	}
+	return path + "." + name
+}
+
+// setValue copies the PDU value into a scalar struct member or []byte
+func setValue(pdu gosnmp.SnmpPDU, v reflect.Value, path string) error {
+	val, err := getAsValue(pdu, v.Type())
+	if val.IsValid() {
+		v.Set(val)
+	}
+	if err != nil {
+		return &FieldError{Field: path, OID: pdu.Name, Err: err}
+	}
+	return nil
+}
+
+// tableMatch checks if the PDU name falls under the table entry OID and splits the remainder into the
+// column sub-identifier and the instance index
+func tableMatch(entryOid string, pduName string) (string, string, bool) {
+	prefix := strings.TrimSuffix(entryOid, ".") + "."
+	if !strings.HasPrefix(pduName, prefix) {
+		return "", "", false
+	}
+	parts := strings.SplitN(pduName[len(prefix):], ".", 2)
+	if len(parts) != 2 || len(parts[0]) == 0 || len(parts[1]) == 0 {
+		return "", "", false
+	}
+	return parts[0], parts[1], true
+}
+
+// assignToTable places the PDU value into the proper column of the row matching the PDU instance index,
+// creating the row if needed.  The table member v must be a map[string]Row or []Row.
+func assignToTable(table *tableInfo, pdu gosnmp.SnmpPDU, v reflect.Value, path string, rows rowIndexes) (bool, error) {
+	column, index, found := tableMatch(table.entry, pdu.Name)
+	if !found {
+		return false, nil
+	}
+	t := v.Type()
+	rowT := t.Elem()
+	if rowT.Kind() != reflect.Struct {
+		return true, &FieldError{Field: path, OID: pdu.Name, Err: ErrUnsupportedField}
+	}
+	colField, ok := table.columns[column]
+	if !ok {
+		return false, nil
+	}
+	if names := table.enums[column]; names != nil {
+		pdu = enumPDU(names, pdu, rowT.Field(colField).Type)
+	}
+	if h := table.hints[column]; h != nil {
+		pdu = hintPDU(h, pdu, rowT.Field(colField).Type)
+	}
+	indexField := table.index
+	colPath := path + "[" + index + "]." + rowT.Field(colField).Name
+	switch v.Kind() {
+	case reflect.Map:
+		if t.Key().Kind() != reflect.String {
+			return true, &FieldError{Field: path, OID: pdu.Name, Err: ErrUnsupportedField}
+		}
+		if v.IsNil() {
+			v.Set(reflect.MakeMap(t))
+		}
+		key := reflect.ValueOf(index).Convert(t.Key())
+		row := reflect.New(rowT).Elem()
+		var indexErr error
+		if existing := v.MapIndex(key); existing.IsValid() {
+			row.Set(existing)
+		} else if indexField >= 0 {
+			indexErr = setRowIndex(index, row, indexField, path, pdu.Name)
+		}
+		err := setValue(pdu, row.Field(colField), colPath)
+		v.SetMapIndex(key, row)
+		return true, errors.Join(indexErr, err)
+	case reflect.Slice:
+		if indexField < 0 {
+			return true, &FieldError{Field: path, OID: pdu.Name, Err: ErrUnsupportedField}
+		}
+		if i := rows.find(v, path, indexField, index); i >= 0 {
+			return true, setValue(pdu, v.Index(i).Field(colField), colPath)
+		}
+		row := reflect.New(rowT).Elem()
+		if err := setRowIndex(index, row, indexField, path, pdu.Name); err != nil {
+			// The row could never be found again, so it isn't added
+			return true, err
+		}
+		err := setValue(pdu, row.Field(colField), colPath)
+		v.Set(reflect.Append(v, row))
+		rows.added(v, path, index)
+		return true, err
+	}
+	return false, nil
+}
+
+// setRowIndex stores the instance index into the index member of a table row, returning a FieldError if
+// the member can't hold it, such as a compound index for an integer member
+func setRowIndex(index string, row reflect.Value, indexField int, path string, oid string) error {
+	if err := setIndexValue(index, row.Field(indexField)); err != nil {
+		return &FieldError{Field: path + "[" + index + "]." + row.Type().Field(indexField).Name, OID: oid, Err: err}
+	}
+	return nil
+}
+
+/*
+rowIndexes holds the position of each row of the []Row table members marshaled into by one series of PDUs,
+by the string form of its index, so that each PDU doesn't have to search the rows.  It is keyed by the path
+to the member, and each member's positions are found when it is first marshaled into.  Nothing else may
+change the rows during the series.
+*/
+type rowIndexes map[string]map[string]int
+
+// find returns the position of the row with the index in the slice v at path, or -1 if there isn't one.  With
+// nil rowIndexes the rows are searched.
+func (r rowIndexes) find(v reflect.Value, path string, indexField int, index string) int {
+	if r == nil {
+		for i := 0; i < v.Len(); i++ {
+			if fmt.Sprint(v.Index(i).Field(indexField).Interface()) == index {
+				return i
 			}
-		case reflect.Float32, reflect.Float64:
-			if _, found := processOidTag(tag, pdu.Name); found {
-				v.SetFloat(GetAsFloat64(pdu))
-				return true
+		}
+		return -1
+	}
+	pos, ok := r[path]
+	if !ok {
+		pos = make(map[string]int, v.Len())
+		for i := v.Len() - 1; i >= 0; i-- {
+			// Going backwards leaves the first of any rows with the same index, as the search finds
+			pos[fmt.Sprint(v.Index(i).Field(indexField).Interface())] = i
+		}
+		r[path] = pos
+	}
+	if i, ok := pos[index]; ok {
+		return i
+	}
+	return -1
+}
+
+// added records the row just appended to the slice v at path
+func (r rowIndexes) added(v reflect.Value, path string, index string) {
+	if r != nil {
+		r[path][index] = v.Len() - 1
+	}
+}
+
+// setIndexValue parses an index, or a portion of one, into a string or integer member
+func setIndexValue(index string, v reflect.Value) error {
+	switch v.Kind() {
+	case reflect.String:
+		v.SetString(index)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		i, err := strconv.ParseInt(index, 10, 64)
+		if err != nil || v.OverflowInt(i) {
+			return ErrConversion
+		}
+		v.SetInt(i)
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		i, err := strconv.ParseUint(index, 10, 64)
+		if err != nil || v.OverflowUint(i) {
+			return ErrConversion
+		}
+		v.SetUint(i)
+	default:
+		return ErrUnsupportedField
+	}
+	return nil
+}
+
+/*
+assignToMap stores the PDU value into the map member v.  The keys are the values captured by the oidx pattern
+and how they form the map key depends on the map type:
+
+	map[string]T             the keys are joined with "." to form a single key
+	map[K]T                  K is a struct and each key is stored in the member of K given by keyFields
+	map[string]map[string]T  each key selects the next level of map, any left over at the last level are joined
+*/
+func assignToMap(keys []string, keyFields []int, pdu gosnmp.SnmpPDU, v reflect.Value, path string) error {
+	t := v.Type()
+	var key reflect.Value
+	switch t.Key().Kind() {
+	case reflect.Struct:
+		path += "[" + strings.Join(keys, ".") + "]"
+		key = reflect.New(t.Key()).Elem()
+		for g, field := range keyFields {
+			if field < 0 || g >= len(keys) {
+				continue
 			}
-		case reflect.String:
-			if _, found := processOidTag(tag, pdu.Name); found {
-				v.SetString(GetAsString(pdu))
-				return true
+			if err := setIndexValue(keys[g], key.Field(field)); err != nil {
+				return &FieldError{Field: path, OID: pdu.Name, Err: err}
 			}
-		case reflect.Slice:
-			if _, found := processOidTag(tag, pdu.Name); found {
-				// Only []byte is supported here
-				if v.Type().Elem().Kind() == reflect.Uint8 {
-					v.SetBytes(GetAsBytes(pdu))
-				} else {
-					panic(fmt.Errorf("[]byte are the only slice types supported"))
-				}
-				return true
+		}
+	case reflect.String:
+		if t.Elem().Kind() == reflect.Map && len(keys) > 1 {
+			key = reflect.ValueOf(keys[0]).Convert(t.Key())
+			inner := reflect.New(t.Elem()).Elem()
+			if existing := v.MapIndex(key); existing.IsValid() {
+				inner.Set(existing)
 			}
-		case reflect.Struct:
-			found := MarshalPDUToStruct(pdu, v.Addr().Interface())
-			return found
-		case reflect.Ptr:
-			// We only deal with pointers to structs here
-			if v.Type().Elem().Kind() == reflect.Struct {
+			err := assignToMap(keys[1:], nil, pdu, inner, path+"["+keys[0]+"]")
+			if err == nil || errors.Is(err, ErrConversion) {
 				if v.IsNil() {
-					v.Set(reflect.New(v.Type().Elem()))
+					v.Set(reflect.MakeMap(t))
 				}
-				found := MarshalPDUToStruct(pdu, v.Interface())
-				return found
+				v.SetMapIndex(key, inner)
 			}
+			return err
 		}
+		k := strings.Join(keys, ".")
+		path += "[" + k + "]"
+		key = reflect.ValueOf(k).Convert(t.Key())
+	default:
+		return &FieldError{Field: path, OID: pdu.Name, Err: ErrUnsupportedField}
+	}
+	val, err := getAsValue(pdu, t.Elem())
+	if !val.IsValid() {
+		return &FieldError{Field: path, OID: pdu.Name, Err: err}
 	}
-	return false
+	if v.IsNil() {
+		v.Set(reflect.MakeMap(t))
+	}
+	v.SetMapIndex(key, val)
+	if err != nil {
+		return &FieldError{Field: path, OID: pdu.Name, Err: err}
+	}
+	return nil
 }
 
-func processOidTag(tag reflect.StructTag, pduName string) ([]string, bool) {
-	var oidMatches []string
-	processValue := false
-	if oid := tag.Get("oid"); len(oid) > 0 && oid == pduName {
-		processValue = true
-	} else if strings.HasPrefix(string(tag), "oidx:") {
-		if oid = tag.Get("oidx"); len(oid) > 0 {
-			processValue, oidMatches = oidxMatch(oid, pduName)
-		} else if oid = string(tag)[5:]; len(oid) > 0 {
-			processValue, oidMatches = oidxMatch(oid, pduName)
+// Types which are converted from the PDU as a whole rather than by their kind
+var valueTypes = map[reflect.Type]func(gosnmp.SnmpPDU) (reflect.Value, error){
+	typeIP:       getIPValue,
+	typeAddr:     getAddrValue,
+	typePrefix:   getPrefixValue,
+	typeDuration: getDurationValue,
+	typeTime:     getTimeValue,
+
+	typeHardwareAddr: getHardwareAddrValue,
+	typeMac6:         getMac6Value,
+}
+
+// getAsValue converts the PDU value into a value of type t, which must be one of the valueTypes or an
+// integer, float, string or []byte type.  If the PDU value can't be cleanly converted, the value from the GetAs functions is still returned
+// along with ErrConversion.  An invalid value and ErrUnsupportedField is returned for other types.
+func getAsValue(pdu gosnmp.SnmpPDU, t reflect.Type) (reflect.Value, error) {
+	if get, ok := valueTypes[t]; ok {
+		return get(pdu)
+	}
+	if names := typeEnumNames(t); names != nil {
+		pdu = enumPDU(names, pdu, t)
+	}
+	var val reflect.Value
+	switch t.Kind() {
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		val = reflect.ValueOf(GetAsUint64(pdu))
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		val = reflect.ValueOf(GetAsInt64(pdu))
+	case reflect.Float32, reflect.Float64:
+		val = reflect.ValueOf(GetAsFloat64(pdu))
+	case reflect.String:
+		return reflect.ValueOf(GetAsString(pdu)).Convert(t), nil
+	case reflect.Slice:
+		if t.Elem().Kind() == reflect.Uint8 {
+			return reflect.ValueOf(GetAsBytes(pdu)).Convert(t), nil
 		}
+		return reflect.Value{}, ErrUnsupportedField
+	default:
+		return reflect.Value{}, ErrUnsupportedField
 	}
-	return oidMatches, processValue
+	var err error
+	switch t.Kind() {
+	case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+		err = checkInteger(pdu, t.Bits(), false)
+	case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+		err = checkInteger(pdu, t.Bits(), true)
+	default:
+		err = checkFloat(pdu, t.Bits())
+	}
+	if err != nil {
+		return val.Convert(t), fmt.Errorf("%w: %w", ErrConversion, err)
+	}
+	return val.Convert(t), nil
 }
 
-// oidxMatch will take a RegX pattern and match it against the PDU Name value
-// Any captured values will be returned
-func oidxMatch(oidPattern string, pduName string) (bool, []string) {
-	var (
-		rx  *regexp.Regexp
-		err error
-	)
-	if rx, err = regexp.Compile(oidPattern); err != nil {
-		return false, nil
+// Names permitted in the asn struct tag along with the ASN.1 type they produce
+var asnTagTypes = map[string]gosnmp.Asn1BER{
+	"integer":          gosnmp.Integer,
+	"octetstring":      gosnmp.OctetString,
+	"ipaddress":        gosnmp.IPAddress,
+	"objectidentifier": gosnmp.ObjectIdentifier,
+	"counter32":        gosnmp.Counter32,
+	"gauge32":          gosnmp.Gauge32,
+	"timeticks":        gosnmp.TimeTicks,
+	"counter64":        gosnmp.Counter64,
+	"uinteger32":       gosnmp.Uinteger32,
+}
+
+/*
+Given a struct with oid tags, this function builds a slice of PDUs from the struct member values and is
+suitable for passing into gosnmp.Set().  It is the reverse of MarshalPDUsToStruct().  For example:
+
+	var s struct {
+		SysContact    string `oid:".1.3.6.1.2.1.1.4.0"`
+		SysLocation   string `oid:".1.3.6.1.2.1.1.6.0"`
+		IfAdminStatus int    `oid:".1.3.6.1.2.1.2.2.1.7.6"`
 	}
-	vars := rx.FindStringSubmatch(pduName)
-	if vars == nil {
-		return false, nil
+	s.SysContact = "noc@example.com"
+	s.SysLocation = "Rack 12"
+	s.IfAdminStatus = 2
+	result, err := gosnmp.Default.Set(MarshalStructToPDUs(&s))
+
+The ASN.1 type of each PDU is derived from the Go type of the struct member:
+
+	int, int8, int16, int32, int64  -> Integer
+	uint, uint8, uint16, uint32     -> Gauge32
+	uint64                          -> Counter64
+	string, []byte, float32/float64 -> OctetString
+
+Members of type net.IP and netip.Addr produce IPAddress PDUs for IPv4 addresses and InetAddress style
+OctetStrings for IPv6 addresses.  A netip.Prefix produces an OctetString in text form.  A time.Duration
+produces TimeTicks and a time.Time produces a DateAndTime OctetString.  A net.HardwareAddr or [6]byte
+produces an OctetString of the raw address bytes.  A string member with an enum tag produces an Integer
+when it holds one of the names in the tag.
+
+When the Go type is ambiguous, an asn tag can be used to pick the ASN.1 type explicitly.  Allowed values are
+Integer, OctetString, IPAddress, ObjectIdentifier, Counter32, Gauge32, TimeTicks, Counter64 and Uinteger32:
+
+	var s struct {
+		IpForwarding int    `oid:".1.3.6.1.2.1.4.1.0" asn:"Integer"`
+		DefaultGw    string `oid:".1.3.6.1.2.1.4.21.1.7.0.0.0.0" asn:"IPAddress"`
+		TimeOut      uint32 `oid:".1.3.6.1.4.1.9999.1.0" asn:"TimeTicks"`
+	}
+
+Nested structs and non-nil pointers to structs are also processed.  Fields with oidx tags are skipped since
+there is no single OID to set, as are fields whose value cannot be represented by the requested ASN.1 type
+(such as a number outside the 32 bit range of an Integer or Gauge32), fields whose oid tag gives a name
+which cannot be resolved (see ResolveName()) and fields with an invalid enum tag.
+*/
+func MarshalStructToPDUs(source interface{}) []gosnmp.SnmpPDU {
+	if source == nil {
+		return []gosnmp.SnmpPDU{}
+	}
+	srcV := reflect.Indirect(reflect.ValueOf(source))
+	if srcV.Kind() != reflect.Struct {
+		return []gosnmp.SnmpPDU{}
 	}
-	return true, vars
+	srcT := srcV.Type()
+	result := make([]gosnmp.SnmpPDU, 0, srcT.NumField())
+	for i := 0; i < srcT.NumField(); i++ {
+		fInfo := srcT.Field(i)
+		if fInfo.PkgPath != "" {
+			// unexported
+			continue
+		}
+		field := srcV.Field(i)
+		if oid := fInfo.Tag.Get("oid"); len(oid) > 0 {
+			oid, err := resolveTag(oid)
+			value, ok := enumValue(fInfo, field)
+			if err != nil || !ok {
+				continue
+			}
+			if pdu, ok := buildPDU(oid, fInfo.Tag.Get("asn"), value); ok {
+				result = append(result, pdu)
+			}
+			continue
+		}
+		switch field.Kind() {
+		case reflect.Ptr:
+			if !field.IsNil() && field.Elem().Kind() == reflect.Struct {
+				result = append(result, MarshalStructToPDUs(field.Interface())...)
+			}
+		case reflect.Struct:
+			result = append(result, MarshalStructToPDUs(field.Interface())...)
+		}
+	}
+	return result
 }
 
-func assignToMap(key string, pdu gosnmp.SnmpPDU, v reflect.Value) {
-	t := v.Type()
-	if v.IsNil() {
-		v.Set(reflect.MakeMap(t))
+// buildPDU creates a PDU for the given OID from a struct member value.  If asnName is empty, the ASN.1
+// type is derived from the kind of the value.
+func buildPDU(oid string, asnName string, v reflect.Value) (gosnmp.SnmpPDU, bool) {
+	pdu := gosnmp.SnmpPDU{Name: oid}
+	if len(asnName) > 0 {
+		var ok bool
+		if pdu.Type, ok = asnTagTypes[strings.ToLower(asnName)]; !ok {
+			return pdu, false
+		}
+	}
+	switch v.Type() {
+	case typeIP, typeAddr, typePrefix:
+		var ok bool
+		pdu.Type, pdu.Value, ok = ipPDUValue(pdu.Type, v)
+		return pdu, ok
+	case typeDuration, typeTime:
+		var ok bool
+		pdu.Type, pdu.Value, ok = timePDUValue(pdu.Type, v)
+		return pdu, ok
+	case typeHardwareAddr, typeMac6:
+		var ok bool
+		pdu.Type, pdu.Value, ok = macPDUValue(pdu.Type, v)
+		return pdu, ok
+	}
+	if len(asnName) == 0 {
+		switch v.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			pdu.Type = gosnmp.Integer
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32:
+			pdu.Type = gosnmp.Gauge32
+		case reflect.Uint64:
+			pdu.Type = gosnmp.Counter64
+		case reflect.String, reflect.Float32, reflect.Float64:
+			pdu.Type = gosnmp.OctetString
+		case reflect.Slice:
+			if v.Type().Elem().Kind() != reflect.Uint8 {
+				return pdu, false
+			}
+			pdu.Type = gosnmp.OctetString
+		default:
+			return pdu, false
+		}
 	}
-	et := t.Elem()
-	v.SetMapIndex(reflect.ValueOf(key), getAsValue(pdu, et.Kind()))
+	var ok bool
+	pdu.Value, ok = getPDUValue(pdu.Type, v)
+	return pdu, ok
 }
 
-func getAsValue(pdu gosnmp.SnmpPDU, destKind reflect.Kind) reflect.Value {
-	switch destKind {
-	case reflect.Uint:
-		return reflect.ValueOf(GetAsUint(pdu))
-	case reflect.Uint32:
-		return reflect.ValueOf(GetAsUint32(pdu))
-	case reflect.Uint64:
-		return reflect.ValueOf(GetAsUint64(pdu))
-	case reflect.Int:
-		return reflect.ValueOf(GetAsInt(pdu))
-	case reflect.Int32:
-		return reflect.ValueOf(GetAsInt32(pdu))
-	case reflect.Int64:
-		return reflect.ValueOf(GetAsInt64(pdu))
-	case reflect.Float32:
-		return reflect.ValueOf(GetAsFloat32(pdu))
-	case reflect.Float64:
-		return reflect.ValueOf(GetAsFloat64(pdu))
-	case reflect.String:
-		return reflect.ValueOf(GetAsString(pdu))
+// getPDUValue converts a struct member value into the Go type gosnmp expects when encoding the ASN.1 type
+func getPDUValue(asnType gosnmp.Asn1BER, v reflect.Value) (interface{}, bool) {
+	switch asnType {
+	case gosnmp.Integer:
+		switch v.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			if i := v.Int(); i >= math.MinInt32 && i <= math.MaxInt32 {
+				return int(i), true
+			}
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			if i := v.Uint(); i <= math.MaxInt32 {
+				return int(i), true
+			}
+		case reflect.String:
+			if i, err := strconv.ParseInt(v.String(), 10, 32); err == nil {
+				return int(i), true
+			}
+		}
+	case gosnmp.Counter32, gosnmp.Gauge32, gosnmp.TimeTicks, gosnmp.Uinteger32:
+		switch v.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			if i := v.Int(); i >= 0 && i <= math.MaxUint32 {
+				return uint32(i), true
+			}
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			if i := v.Uint(); i <= math.MaxUint32 {
+				return uint32(i), true
+			}
+		case reflect.String:
+			if i, err := strconv.ParseUint(v.String(), 10, 32); err == nil {
+				return uint32(i), true
+			}
+		}
+	case gosnmp.Counter64:
+		switch v.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			if v.Int() >= 0 {
+				return uint64(v.Int()), true
+			}
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			return v.Uint(), true
+		case reflect.String:
+			if i, err := strconv.ParseUint(v.String(), 10, 64); err == nil {
+				return i, true
+			}
+		}
+	case gosnmp.OctetString:
+		switch v.Kind() {
+		case reflect.Int, reflect.Int8, reflect.Int16, reflect.Int32, reflect.Int64:
+			return strconv.FormatInt(v.Int(), 10), true
+		case reflect.Uint, reflect.Uint8, reflect.Uint16, reflect.Uint32, reflect.Uint64:
+			return strconv.FormatUint(v.Uint(), 10), true
+		case reflect.Float32:
+			return strconv.FormatFloat(v.Float(), 'f', -1, 32), true
+		case reflect.Float64:
+			return strconv.FormatFloat(v.Float(), 'f', -1, 64), true
+		case reflect.String:
+			return v.String(), true
+		case reflect.Slice:
+			if v.Type().Elem().Kind() == reflect.Uint8 {
+				return v.Bytes(), true
+			}
+		}
+	case gosnmp.IPAddress, gosnmp.ObjectIdentifier:
+		switch v.Kind() {
+		case reflect.String:
+			return v.String(), true
+		case reflect.Slice:
+			if asnType == gosnmp.IPAddress && v.Type().Elem().Kind() == reflect.Uint8 {
+				return v.Bytes(), true
+			}
+		}
 	}
-	panic(fmt.Errorf("unsupported destination type, must be Int, Uint, Float, or String"))
+	return nil, false
 }
@@ -1,9 +1,16 @@
 package gosnmpHelper
 
 import (
+	"errors"
+	"fmt"
 	"github.com/davecgh/go-spew/spew"
 	snmp "github.com/gosnmp/gosnmp"
+	"github.com/jjcinaz/gosnmpHelper/snmptest"
+	"math"
+	"net"
+	"net/netip"
 	"reflect"
+	"sort"
 	"testing"
 	"time"
 )
@@ -35,20 +42,12 @@ func TestMarshalPDUToStruct1(t *testing.T) {
 	var (
 		err error
 	)
-	params := &snmp.GoSNMP{
-		Target:    "192.168.91.1",
-		Port:      161,
-		Community: "public",
-		Version:   snmp.Version2c,
-		Timeout:   time.Duration(5) * time.Second,
-		Retries:   3,
-		Logger:    nil,
-	}
-	err = params.Connect()
+	agent, err := snmptest.NewAgentFromWalkFile("testdata/router.walk")
 	if err != nil {
-		t.Errorf("Connect() err: %v", err)
+		t.Fatalf("NewAgentFromWalkFile() err: %v", err)
 	}
-	defer params.Conn.Close()
+	defer agent.Close()
+	params := agent.Client()
 
 	info := SysInfo2{}
 	err = params.BulkWalk(".1.3.6.1.2.1.2.2.1",
@@ -67,27 +66,19 @@ func TestMarshalPDUToStruct1(t *testing.T) {
 	if err != nil {
 		t.Errorf("bulkwalk failure %s", err)
 	}
-	spew.Dump(info)
+	checkRouterInfo(t, info)
 }
 
 func TestMarshalPDUToStruct2(t *testing.T) {
 	var (
 		err error
 	)
-	params := &snmp.GoSNMP{
-		Target:    "192.168.91.1",
-		Port:      161,
-		Community: "public",
-		Version:   snmp.Version2c,
-		Timeout:   time.Duration(5) * time.Second,
-		Retries:   3,
-		Logger:    nil,
-	}
-	err = params.Connect()
+	agent, err := snmptest.NewAgentFromWalkFile("testdata/router.walk")
 	if err != nil {
-		t.Errorf("Connect() err: %v", err)
+		t.Fatalf("NewAgentFromWalkFile() err: %v", err)
 	}
-	defer params.Conn.Close()
+	defer agent.Close()
+	params := agent.Client()
 
 	info := SysInfo2{
 		Intfs: new(SysIntfs),
@@ -108,7 +99,26 @@ func TestMarshalPDUToStruct2(t *testing.T) {
 	if err != nil {
 		t.Errorf("bulkwalk failure %s", err)
 	}
-	spew.Dump(info)
+	checkRouterInfo(t, info)
+}
+
+// checkRouterInfo checks the values from testdata/router.walk
+func checkRouterInfo(t *testing.T, info SysInfo2) {
+	t.Helper()
+	want := SysInfo2{
+		SysDesc:     "Linux router 5.10.0-21-amd64 #1 SMP x86_64",
+		SysObjectId: ".1.3.6.1.4.1.8072.3.2.10",
+		SysUpTime:   7654321,
+		SysContact:  "noc@example.com",
+		SysName:     "router",
+		Intfs: &SysIntfs{
+			IfDesc:       map[string]string{"1": "lo", "2": "eth0", "6": "eth1"},
+			IfOperStatus: map[string]int{"1": 1, "2": 1, "6": 2},
+		},
+	}
+	if !reflect.DeepEqual(info, want) {
+		t.Errorf("got %s\nwant %s", spew.Sdump(info), spew.Sdump(want))
+	}
 }
 
 type Test1 struct {
@@ -196,3 +206,498 @@ func TestGetOidsFromStructTags3(t *testing.T) {
 	var x int
 	GetOidsFromStructTags(x, false)
 }
+
+type Test5 struct {
+	SysContact    string `oid:".1.3.6.1.2.1.1.4.0"`
+	SysLocation   string `oid:".1.3.6.1.2.1.1.6.0"`
+	IfAdminStatus int    `oid:".1.3.6.1.2.1.2.2.1.7.6"`
+	IfSpeed       uint32 `oid:".1.3.6.1.2.1.2.2.1.5.6"`
+	IfHCInOctets  uint64 `oid:".1.3.6.1.2.1.31.1.1.1.6.6"`
+	DefaultGw     string `oid:".1.3.6.1.2.1.4.21.1.7.0.0.0.0" asn:"IPAddress"`
+	Timeout       uint   `oid:".1.3.6.1.4.1.9999.1.0" asn:"TimeTicks"`
+	Ignored       string
+	Nested        *Test4a
+}
+
+func TestMarshalStructToPDUs(t *testing.T) {
+	src := Test5{
+		SysContact:    "noc",
+		SysLocation:   "Rack 12",
+		IfAdminStatus: 2,
+		IfSpeed:       1000000000,
+		IfHCInOctets:  12345678901,
+		DefaultGw:     "10.0.0.1",
+		Timeout:       500,
+		Nested:        &Test4a{InterfaceCount: 4},
+	}
+	want := []snmp.SnmpPDU{
+		{Name: ".1.3.6.1.2.1.1.4.0", Type: snmp.OctetString, Value: "noc"},
+		{Name: ".1.3.6.1.2.1.1.6.0", Type: snmp.OctetString, Value: "Rack 12"},
+		{Name: ".1.3.6.1.2.1.2.2.1.7.6", Type: snmp.Integer, Value: 2},
+		{Name: ".1.3.6.1.2.1.2.2.1.5.6", Type: snmp.Gauge32, Value: uint32(1000000000)},
+		{Name: ".1.3.6.1.2.1.31.1.1.1.6.6", Type: snmp.Counter64, Value: uint64(12345678901)},
+		{Name: ".1.3.6.1.2.1.4.21.1.7.0.0.0.0", Type: snmp.IPAddress, Value: "10.0.0.1"},
+		{Name: ".1.3.6.1.4.1.9999.1.0", Type: snmp.TimeTicks, Value: uint32(500)},
+		{Name: ".1.3.6.1.2.1.2.1.0", Type: snmp.Integer, Value: 4},
+	}
+	if got := MarshalStructToPDUs(&src); !reflect.DeepEqual(got, want) {
+		t.Errorf("MarshalStructToPDUs() = %v, want %v", got, want)
+	}
+	// Round trip back into a struct
+	var dst Test5
+	MarshalPDUsToStruct(MarshalStructToPDUs(src), &dst)
+	if dst.SysLocation != src.SysLocation || dst.IfAdminStatus != src.IfAdminStatus || dst.IfHCInOctets != src.IfHCInOctets {
+		t.Errorf("round trip = %+v, want %+v", dst, src)
+	}
+}
+
+func TestMarshalStructToPDUsRange(t *testing.T) {
+	type ranges struct {
+		Gauge    uint64 `oid:".1.3.6.1.4.1.9999.1.1" asn:"Gauge32"`
+		Ticks    int64  `oid:".1.3.6.1.4.1.9999.1.2" asn:"TimeTicks"`
+		Integer  int64  `oid:".1.3.6.1.4.1.9999.1.3" asn:"Integer"`
+		Unsigned uint64 `oid:".1.3.6.1.4.1.9999.1.4" asn:"Integer"`
+		Negative int    `oid:".1.3.6.1.4.1.9999.1.5" asn:"Counter32"`
+		Text     string `oid:".1.3.6.1.4.1.9999.1.6" asn:"Integer"`
+		Default  int    `oid:".1.3.6.1.4.1.9999.1.7"`
+	}
+	tests := []struct {
+		name string
+		src  ranges
+		want []snmp.SnmpPDU
+	}{
+		{"in range", ranges{Gauge: math.MaxUint32, Ticks: 5, Integer: math.MinInt32, Unsigned: math.MaxInt32, Text: "-7", Default: math.MaxInt32},
+			[]snmp.SnmpPDU{
+				{Name: ".1.3.6.1.4.1.9999.1.1", Type: snmp.Gauge32, Value: uint32(math.MaxUint32)},
+				{Name: ".1.3.6.1.4.1.9999.1.2", Type: snmp.TimeTicks, Value: uint32(5)},
+				{Name: ".1.3.6.1.4.1.9999.1.3", Type: snmp.Integer, Value: math.MinInt32},
+				{Name: ".1.3.6.1.4.1.9999.1.4", Type: snmp.Integer, Value: math.MaxInt32},
+				{Name: ".1.3.6.1.4.1.9999.1.5", Type: snmp.Counter32, Value: uint32(0)},
+				{Name: ".1.3.6.1.4.1.9999.1.6", Type: snmp.Integer, Value: -7},
+				{Name: ".1.3.6.1.4.1.9999.1.7", Type: snmp.Integer, Value: math.MaxInt32},
+			}},
+		{"out of range", ranges{Gauge: 1<<32 + 5, Ticks: 1 << 32, Integer: 1 << 40, Unsigned: math.MaxUint64, Negative: -1,
+			Text: "4294967296", Default: math.MinInt32 - 1}, []snmp.SnmpPDU{}},
+	}
+	for _, tt := range tests {
+		if got := MarshalStructToPDUs(&tt.src); !reflect.DeepEqual(got, tt.want) {
+			t.Errorf("%s: MarshalStructToPDUs() = %v, want %v", tt.name, got, tt.want)
+		}
+	}
+}
+
+type IfRow struct {
+	IfIndex      int    `oidcol:"index"`
+	IfDesc       string `oidcol:"2"`
+	IfOperStatus int    `oidcol:"8"`
+}
+
+type ArpRow struct {
+	Index   string `oidcol:"index"`
+	PhysAdr []byte `oidcol:"2"`
+	Type    int    `oidcol:"4"`
+}
+
+type Test6 struct {
+	SysDesc    string `oid:".1.3.6.1.2.1.1.1.0"`
+	Nested     Test4a
+	IfTable    map[string]IfRow  `oidtable:".1.3.6.1.2.1.2.2.1"`
+	ArpEntries map[string]ArpRow `oidtable:".1.3.6.1.2.1.4.22.1"`
+}
+
+func TestMarshalPDUToStructTable(t *testing.T) {
+	pdus := []snmp.SnmpPDU{
+		{Name: ".1.3.6.1.2.1.2.2.1.2.1", Type: snmp.OctetString, Value: []byte("lo")},
+		{Name: ".1.3.6.1.2.1.2.2.1.2.6", Type: snmp.OctetString, Value: []byte("eth0")},
+		{Name: ".1.3.6.1.2.1.2.2.1.5.6", Type: snmp.Gauge32, Value: uint32(1000)},
+		{Name: ".1.3.6.1.2.1.2.2.1.8.1", Type: snmp.Integer, Value: 1},
+		{Name: ".1.3.6.1.2.1.2.2.1.8.6", Type: snmp.Integer, Value: 2},
+		{Name: ".1.3.6.1.2.1.4.22.1.2.3.10.0.0.1", Type: snmp.OctetString, Value: []byte{0, 1, 2, 3, 4, 5}},
+		{Name: ".1.3.6.1.2.1.4.22.1.4.3.10.0.0.1", Type: snmp.Integer, Value: 3},
+	}
+	var info Test6
+	MarshalPDUsToStruct(pdus, &info)
+	wantTable := map[string]IfRow{
+		"1": {IfIndex: 1, IfDesc: "lo", IfOperStatus: 1},
+		"6": {IfIndex: 6, IfDesc: "eth0", IfOperStatus: 2},
+	}
+	if !reflect.DeepEqual(info.IfTable, wantTable) {
+		t.Errorf("IfTable = %v, want %v", info.IfTable, wantTable)
+	}
+	wantArp := map[string]ArpRow{
+		"3.10.0.0.1": {Index: "3.10.0.0.1", PhysAdr: []byte{0, 1, 2, 3, 4, 5}, Type: 3},
+	}
+	if !reflect.DeepEqual(info.ArpEntries, wantArp) {
+		t.Errorf("ArpEntries = %v, want %v", info.ArpEntries, wantArp)
+	}
+	// The first matching field wins, so the slice is only filled when it's the sole table member
+	var list struct {
+		IfList []IfRow `oidtable:".1.3.6.1.2.1.2.2.1"`
+	}
+	MarshalPDUsToStruct(pdus, &list)
+	wantList := []IfRow{
+		{IfIndex: 1, IfDesc: "lo", IfOperStatus: 1},
+		{IfIndex: 6, IfDesc: "eth0", IfOperStatus: 2},
+	}
+	if !reflect.DeepEqual(list.IfList, wantList) {
+		t.Errorf("IfList = %v, want %v", list.IfList, wantList)
+	}
+}
+
+type intArpRow struct {
+	Index int `oidcol:"index"`
+	Type  int `oidcol:"4"`
+}
+
+func TestTableIndex(t *testing.T) {
+	pdu := snmp.SnmpPDU{Name: ".1.3.6.1.2.1.4.22.1.4.3.10.0.0.1", Type: snmp.Integer, Value: 3}
+	var m struct {
+		Arp map[string]intArpRow `oidtable:".1.3.6.1.2.1.4.22.1"`
+	}
+	var fe *FieldError
+	if _, err := MarshalPDUToStructE(pdu, &m); !errors.Is(err, ErrConversion) || !errors.As(err, &fe) || fe.Field != "Arp[3.10.0.0.1].Index" {
+		t.Errorf("map MarshalPDUToStructE() err = %v, want ErrConversion for Arp[3.10.0.0.1].Index", err)
+	}
+	if row := m.Arp["3.10.0.0.1"]; row.Type != 3 {
+		t.Errorf("map row = %+v", row)
+	}
+	var l struct {
+		Arp []intArpRow `oidtable:".1.3.6.1.2.1.4.22.1"`
+	}
+	for i := 0; i < 2; i++ {
+		if _, err := MarshalPDUToStructE(pdu, &l); !errors.Is(err, ErrConversion) {
+			t.Errorf("slice MarshalPDUToStructE() err = %v, want ErrConversion", err)
+		}
+	}
+	if len(l.Arp) != 0 {
+		t.Errorf("slice rows = %+v, want none", l.Arp)
+	}
+
+	// Rows are found again in a large table, in slices changed between calls and in new slices
+	var pdus []snmp.SnmpPDU
+	for col := 2; col <= 8; col += 6 {
+		for i := 1; i <= 2000; i++ {
+			pdus = append(pdus, snmp.SnmpPDU{Name: fmt.Sprintf(".1.3.6.1.2.1.2.2.1.%d.%d", col, i), Type: snmp.Integer, Value: i})
+		}
+	}
+	for pass := 0; pass < 2; pass++ {
+		var list struct {
+			IfList []IfRow `oidtable:".1.3.6.1.2.1.2.2.1"`
+		}
+		list.IfList = []IfRow{{IfIndex: 2000}, {IfIndex: 1}}
+		MarshalPDUsToStruct(pdus, &list)
+		if len(list.IfList) != 2000 || list.IfList[0].IfOperStatus != 2000 || list.IfList[1].IfDesc != "1" ||
+			list.IfList[1999].IfIndex != 1999 || list.IfList[1999].IfOperStatus != 1999 {
+			t.Fatalf("IfList has %d rows, first %+v, last %+v", len(list.IfList), list.IfList[0], list.IfList[len(list.IfList)-1])
+		}
+	}
+
+	// Rows reordered in place between calls are still found
+	var sorted struct {
+		IfList []IfRow `oidtable:".1.3.6.1.2.1.2.2.1"`
+	}
+	err := MarshalPDUsToStructE([]snmp.SnmpPDU{
+		{Name: ".1.3.6.1.2.1.2.2.1.8.1", Type: snmp.Integer, Value: 1},
+		{Name: ".1.3.6.1.2.1.2.2.1.8.2", Type: snmp.Integer, Value: 2},
+	}, &sorted)
+	if err != nil {
+		t.Fatalf("MarshalPDUsToStructE() err = %v", err)
+	}
+	sort.Slice(sorted.IfList, func(i, j int) bool { return sorted.IfList[i].IfIndex > sorted.IfList[j].IfIndex })
+	for _, m := range []func(snmp.SnmpPDU) error{
+		func(pdu snmp.SnmpPDU) error { _, err := MarshalPDUToStructE(pdu, &sorted); return err },
+		func(pdu snmp.SnmpPDU) error { return MarshalPDUsToStructE([]snmp.SnmpPDU{pdu}, &sorted) },
+	} {
+		if err := m(snmp.SnmpPDU{Name: ".1.3.6.1.2.1.2.2.1.8.1", Type: snmp.Integer, Value: 3}); err != nil {
+			t.Fatalf("marshal err = %v", err)
+		}
+	}
+	if want := []IfRow{{IfIndex: 2, IfOperStatus: 2}, {IfIndex: 1, IfOperStatus: 3}}; !reflect.DeepEqual(sorted.IfList, want) {
+		t.Errorf("sorted IfList = %+v, want %+v", sorted.IfList, want)
+	}
+}
+
+type badTagNested struct {
+	IfNumber int    `oid:".1.3.6.1.2.1.2.1.0"`
+	Unknown  string `oid:"NO-SUCH-MIB::foo.0"`
+}
+
+type badTagInfo struct {
+	SysName string `oid:".1.3.6.1.2.1.1.5.0"`
+	Status  string `oid:".1.3.6.1.2.1.2.2.1.8.1" enum:"up"`
+	Hint    string `oid:".1.3.6.1.2.1.2.2.1.6.1" hint:"1q"`
+	Nested  badTagNested
+}
+
+func TestLegacyBadTags(t *testing.T) {
+	// The functions which predate NewCodec() skip members whose tags can't be used instead of panicking
+	if got, want := GetOidsFromStructTags(badTagInfo{}, true), []string{".1.3.6.1.2.1.1.5.0", ".1.3.6.1.2.1.2.1.0"}; !reflect.DeepEqual(got, want) {
+		t.Errorf("GetOidsFromStructTags() = %v, want %v", got, want)
+	}
+	pdus := []snmp.SnmpPDU{
+		{Name: ".1.3.6.1.2.1.1.5.0", Type: snmp.OctetString, Value: []byte("router")},
+		{Name: ".1.3.6.1.2.1.2.2.1.8.1", Type: snmp.Integer, Value: 1},
+		{Name: ".1.3.6.1.2.1.2.1.0", Type: snmp.Integer, Value: 3},
+	}
+	var info badTagInfo
+	if !MarshalPDUToStruct(pdus[0], &info) || MarshalPDUToStruct(pdus[1], &info) {
+		t.Errorf("MarshalPDUToStruct() found wrong members")
+	}
+	MarshalPDUsToStruct(pdus, &info)
+	if info.SysName != "router" || info.Status != "" || info.Nested.IfNumber != 3 {
+		t.Errorf("MarshalPDUsToStruct() = %+v", info)
+	}
+
+	// The others still report the errors
+	if _, err := MarshalPDUToStructE(pdus[0], &info); !errors.Is(err, ErrInvalidEnum) || !errors.Is(err, ErrUnknownName) {
+		t.Errorf("MarshalPDUToStructE() err = %v, want ErrInvalidEnum and ErrUnknownName", err)
+	}
+	if _, err := NewCodec(reflect.TypeOf(badTagNested{})); !errors.Is(err, ErrUnknownName) {
+		t.Errorf("NewCodec() of nested type err = %v, want ErrUnknownName", err)
+	}
+}
+
+type Test7 struct {
+	SysUpTime uint64 `oid:".1.3.6.1.2.1.1.3.0"`
+	Intfs     SysIntfs
+	Bad       []int             `oid:".1.3.6.1.2.1.1.9.0"`
+	BadMap    map[string]bool   `oidx:"\\.1\\.3\\.6\\.1\\.2\\.1\\.31\\.1\\.1\\.1\\.1\\.(\\d+)"`
+	ArpTable  map[string]ArpRow `oidtable:".1.3.6.1.2.1.4.22.1"`
+}
+
+func TestMarshalPDUToStructE(t *testing.T) {
+	tests := []struct {
+		name      string
+		pdu       snmp.SnmpPDU
+		wantFound bool
+		wantErr   error
+		wantField string
+	}{
+		{name: "Ok", pdu: snmp.SnmpPDU{Name: ".1.3.6.1.2.1.1.3.0", Type: snmp.TimeTicks, Value: uint32(100)}, wantFound: true},
+		{name: "No match", pdu: snmp.SnmpPDU{Name: ".1.3.6.1.2.1.1.99.0", Type: snmp.Integer, Value: 1}},
+		{name: "Conversion", pdu: snmp.SnmpPDU{Name: ".1.3.6.1.2.1.2.2.1.8.6", Type: snmp.OctetString, Value: []byte("up")},
+			wantFound: true, wantErr: ErrConversion, wantField: "Intfs.IfOperStatus[6]"},
+		{name: "Table conversion", pdu: snmp.SnmpPDU{Name: ".1.3.6.1.2.1.4.22.1.4.3.10.0.0.1", Type: snmp.Null},
+			wantFound: true, wantErr: ErrConversion, wantField: "ArpTable[3.10.0.0.1].Type"},
+		{name: "Slice", pdu: snmp.SnmpPDU{Name: ".1.3.6.1.2.1.1.9.0", Type: snmp.Integer, Value: 1},
+			wantFound: true, wantErr: ErrUnsupportedField, wantField: "Bad"},
+		{name: "Map", pdu: snmp.SnmpPDU{Name: ".1.3.6.1.2.1.31.1.1.1.1.6", Type: snmp.OctetString, Value: []byte("Gi0/1")},
+			wantFound: true, wantErr: ErrUnsupportedField, wantField: "BadMap[6]"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var dest Test7
+			found, err := MarshalPDUToStructE(tt.pdu, &dest)
+			if found != tt.wantFound {
+				t.Errorf("MarshalPDUToStructE() found = %v, want %v", found, tt.wantFound)
+			}
+			if !errors.Is(err, tt.wantErr) {
+				t.Errorf("MarshalPDUToStructE() err = %v, want %v", err, tt.wantErr)
+			}
+			var fe *FieldError
+			if errors.As(err, &fe) && fe.Field != tt.wantField {
+				t.Errorf("MarshalPDUToStructE() field = %q, want %q", fe.Field, tt.wantField)
+			}
+		})
+	}
+	var x int
+	if _, err := MarshalPDUToStructE(snmp.SnmpPDU{}, &x); err != ErrNotPointer {
+		t.Errorf("MarshalPDUToStructE() err = %v, want %v", err, ErrNotPointer)
+	}
+	if _, err := MarshalPDUToStructE(snmp.SnmpPDU{}, Test7{}); err != ErrNotPointer {
+		t.Errorf("MarshalPDUToStructE() err = %v, want %v", err, ErrNotPointer)
+	}
+}
+
+func TestMarshalPDUsToStructE(t *testing.T) {
+	pdus := []snmp.SnmpPDU{
+		{Name: ".1.3.6.1.2.1.2.2.1.8.1", Type: snmp.OctetString, Value: []byte("bogus")},
+		{Name: ".1.3.6.1.2.1.1.9.0", Type: snmp.Integer, Value: 1},
+		{Name: ".1.3.6.1.2.1.1.3.0", Type: snmp.TimeTicks, Value: uint32(100)},
+	}
+	var dest Test7
+	err := MarshalPDUsToStructE(pdus, &dest)
+	if !errors.Is(err, ErrConversion) || !errors.Is(err, ErrUnsupportedField) {
+		t.Errorf("MarshalPDUsToStructE() err = %v, want both errors", err)
+	}
+	if dest.SysUpTime != 100 {
+		t.Errorf("SysUpTime = %d, want 100", dest.SysUpTime)
+	}
+}
+
+type FdbKey struct {
+	Vlan int
+	Mac  string
+}
+
+type ArpKey struct {
+	Addr    string
+	IfIndex uint
+}
+
+type Test8 struct {
+	FdbPort map[FdbKey]int `oidx:"\\.1\\.3\\.6\\.1\\.2\\.1\\.17\\.7\\.1\\.2\\.2\\.1\\.2\\.(\\d+)\\.([\\d.]+)"`
+	// Named groups out of member order
+	ArpPhys   map[ArpKey]string         `oidx:"\\.1\\.3\\.6\\.1\\.2\\.1\\.4\\.22\\.1\\.2\\.(?P<ifindex>\\d+)\\.(?P<addr>[\\d.]+)"`
+	ArpType   map[string]int            `oidx:"\\.1\\.3\\.6\\.1\\.2\\.1\\.4\\.22\\.1\\.4\\.(\\d+)\\.([\\d.]+)"`
+	FdbStatus map[string]map[string]int `oidx:"\\.1\\.3\\.6\\.1\\.2\\.1\\.17\\.7\\.1\\.2\\.2\\.1\\.3\\.(\\d+)\\.([\\d.]+)"`
+}
+
+func TestMarshalPDUToStructCompoundIndex(t *testing.T) {
+	pdus := []snmp.SnmpPDU{
+		{Name: ".1.3.6.1.2.1.17.7.1.2.2.1.2.10.0.17.34.51.68.85", Type: snmp.Integer, Value: 5},
+		{Name: ".1.3.6.1.2.1.17.7.1.2.2.1.2.20.0.17.34.51.68.85", Type: snmp.Integer, Value: 6},
+		{Name: ".1.3.6.1.2.1.17.7.1.2.2.1.3.10.0.17.34.51.68.85", Type: snmp.Integer, Value: 3},
+		{Name: ".1.3.6.1.2.1.17.7.1.2.2.1.3.10.0.17.34.51.68.86", Type: snmp.Integer, Value: 1},
+		{Name: ".1.3.6.1.2.1.4.22.1.2.3.10.0.0.1", Type: snmp.OctetString, Value: []byte("x")},
+		{Name: ".1.3.6.1.2.1.4.22.1.4.3.10.0.0.1", Type: snmp.Integer, Value: 3},
+	}
+	var info Test8
+	if err := MarshalPDUsToStructE(pdus, &info); err != nil {
+		t.Fatalf("MarshalPDUsToStructE() err = %v", err)
+	}
+	wantFdb := map[FdbKey]int{{10, "0.17.34.51.68.85"}: 5, {20, "0.17.34.51.68.85"}: 6}
+	if !reflect.DeepEqual(info.FdbPort, wantFdb) {
+		t.Errorf("FdbPort = %v, want %v", info.FdbPort, wantFdb)
+	}
+	wantPhys := map[ArpKey]string{{Addr: "10.0.0.1", IfIndex: 3}: "x"}
+	if !reflect.DeepEqual(info.ArpPhys, wantPhys) {
+		t.Errorf("ArpPhys = %v, want %v", info.ArpPhys, wantPhys)
+	}
+	wantType := map[string]int{"3.10.0.0.1": 3}
+	if !reflect.DeepEqual(info.ArpType, wantType) {
+		t.Errorf("ArpType = %v, want %v", info.ArpType, wantType)
+	}
+	wantStatus := map[string]map[string]int{"10": {"0.17.34.51.68.85": 3, "0.17.34.51.68.86": 1}}
+	if !reflect.DeepEqual(info.FdbStatus, wantStatus) {
+		t.Errorf("FdbStatus = %v, want %v", info.FdbStatus, wantStatus)
+	}
+}
+
+type Test9 struct {
+	AdEntAddr map[string]net.IP     `oidx:"\\.1\\.3\\.6\\.1\\.2\\.1\\.4\\.20\\.1\\.1\\.([\\d.]+)"`
+	PeerAddr  netip.Addr            `oid:".1.3.6.1.2.1.15.3.1.7.10.0.0.2"`
+	Route     netip.Prefix          `oid:".1.3.6.1.4.1.9999.1.0"`
+	Host      netip.Prefix          `oid:".1.3.6.1.4.1.9999.2.0"`
+	InetAddr  map[string]netip.Addr `oidx:"\\.1\\.3\\.6\\.1\\.2\\.1\\.4\\.34\\.1\\.3\\.(.+)"`
+	DefaultGw net.IP                `oid:".1.3.6.1.2.1.4.21.1.7.0.0.0.0"`
+}
+
+func TestMarshalPDUToStructIP(t *testing.T) {
+	pdus := []snmp.SnmpPDU{
+		{Name: ".1.3.6.1.2.1.4.20.1.1.10.0.0.1", Type: snmp.IPAddress, Value: "10.0.0.1"},
+		{Name: ".1.3.6.1.2.1.15.3.1.7.10.0.0.2", Type: snmp.IPAddress, Value: "10.0.0.2"},
+		{Name: ".1.3.6.1.4.1.9999.1.0", Type: snmp.OctetString, Value: []byte("10.1.0.0/16")},
+		{Name: ".1.3.6.1.4.1.9999.2.0", Type: snmp.OctetString, Value: []byte{10, 1, 2, 3}},
+		{Name: ".1.3.6.1.2.1.4.34.1.3.2.16.32.1.13.184.0.0.0.0.0.0.0.0.0.0.0.1", Type: snmp.OctetString,
+			Value: []byte(net.ParseIP("2001:db8::1"))},
+		{Name: ".1.3.6.1.2.1.4.21.1.7.0.0.0.0", Type: snmp.IPAddress, Value: "10.0.0.254"},
+	}
+	var info Test9
+	if err := MarshalPDUsToStructE(pdus, &info); err != nil {
+		t.Fatalf("MarshalPDUsToStructE() err = %v", err)
+	}
+	if !info.AdEntAddr["10.0.0.1"].Equal(net.IP{10, 0, 0, 1}) {
+		t.Errorf("AdEntAddr = %v", info.AdEntAddr)
+	}
+	if info.PeerAddr != netip.MustParseAddr("10.0.0.2") {
+		t.Errorf("PeerAddr = %v", info.PeerAddr)
+	}
+	if info.Route != netip.MustParsePrefix("10.1.0.0/16") || info.Host != netip.MustParsePrefix("10.1.2.3/32") {
+		t.Errorf("Route = %v, Host = %v", info.Route, info.Host)
+	}
+	if info.InetAddr["2.16.32.1.13.184.0.0.0.0.0.0.0.0.0.0.0.1"] != netip.MustParseAddr("2001:db8::1") {
+		t.Errorf("InetAddr = %v", info.InetAddr)
+	}
+	if !info.DefaultGw.Equal(net.IP{10, 0, 0, 254}) {
+		t.Errorf("DefaultGw = %v", info.DefaultGw)
+	}
+
+	want := []snmp.SnmpPDU{
+		{Name: ".1.3.6.1.2.1.15.3.1.7.10.0.0.2", Type: snmp.IPAddress, Value: "10.0.0.2"},
+		{Name: ".1.3.6.1.4.1.9999.1.0", Type: snmp.OctetString, Value: "10.1.0.0/16"},
+		{Name: ".1.3.6.1.4.1.9999.2.0", Type: snmp.OctetString, Value: "10.1.2.3/32"},
+		{Name: ".1.3.6.1.2.1.4.21.1.7.0.0.0.0", Type: snmp.IPAddress, Value: "10.0.0.254"},
+	}
+	if got := MarshalStructToPDUs(&info); !reflect.DeepEqual(got, want) {
+		t.Errorf("MarshalStructToPDUs() = %v, want %v", got, want)
+	}
+
+	_, err := MarshalPDUToStructE(snmp.SnmpPDU{Name: ".1.3.6.1.2.1.15.3.1.7.10.0.0.2", Type: snmp.OctetString, Value: []byte("bogus")}, &info)
+	if !errors.Is(err, ErrConversion) {
+		t.Errorf("MarshalPDUToStructE() err = %v, want %v", err, ErrConversion)
+	}
+}
+
+type Test10 struct {
+	SysUpTime    time.Duration            `oid:".1.3.6.1.2.1.1.3.0"`
+	HrSystemDate time.Time                `oid:".1.3.6.1.2.1.25.1.2.0"`
+	LastChange   map[string]time.Duration `oidx:"\\.1\\.3\\.6\\.1\\.2\\.1\\.2\\.2\\.1\\.9\\.(\\d+)"`
+}
+
+func TestMarshalPDUToStructTime(t *testing.T) {
+	date := []byte{0x07, 0xea, 10, 16, 8, 0, 0, 0, '+', 2, 0}
+	pdus := []snmp.SnmpPDU{
+		{Name: ".1.3.6.1.2.1.1.3.0", Type: snmp.TimeTicks, Value: uint32(12345)},
+		{Name: ".1.3.6.1.2.1.25.1.2.0", Type: snmp.OctetString, Value: date},
+		{Name: ".1.3.6.1.2.1.2.2.1.9.3", Type: snmp.TimeTicks, Value: uint32(100)},
+	}
+	var info Test10
+	if err := MarshalPDUsToStructE(pdus, &info); err != nil {
+		t.Fatalf("MarshalPDUsToStructE() err = %v", err)
+	}
+	if info.SysUpTime != 123450*time.Millisecond {
+		t.Errorf("SysUpTime = %v", info.SysUpTime)
+	}
+	if want := time.Date(2026, 10, 16, 6, 0, 0, 0, time.UTC); !info.HrSystemDate.Equal(want) {
+		t.Errorf("HrSystemDate = %v, want %v", info.HrSystemDate, want)
+	}
+	if info.LastChange["3"] != time.Second {
+		t.Errorf("LastChange = %v", info.LastChange)
+	}
+	want := []snmp.SnmpPDU{
+		{Name: ".1.3.6.1.2.1.1.3.0", Type: snmp.TimeTicks, Value: uint32(12345)},
+		{Name: ".1.3.6.1.2.1.25.1.2.0", Type: snmp.OctetString, Value: date},
+	}
+	if got := MarshalStructToPDUs(&info); !reflect.DeepEqual(got, want) {
+		t.Errorf("MarshalStructToPDUs() = %v, want %v", got, want)
+	}
+	_, err := MarshalPDUToStructE(snmp.SnmpPDU{Name: ".1.3.6.1.2.1.25.1.2.0", Type: snmp.OctetString, Value: []byte{0, 0, 0, 0, 0, 0, 0, 0}}, &info)
+	if !errors.Is(err, ErrConversion) {
+		t.Errorf("MarshalPDUToStructE() err = %v, want %v", err, ErrConversion)
+	}
+}
+
+type Test11 struct {
+	IfPhysAddress map[string]net.HardwareAddr `oidx:"\\.1\\.3\\.6\\.1\\.2\\.1\\.2\\.2\\.1\\.6\\.(\\d+)"`
+	BaseAddress   [6]byte                     `oid:".1.3.6.1.2.1.17.1.1.0"`
+	TextMac       net.HardwareAddr            `oid:".1.3.6.1.4.1.9999.1.0"`
+}
+
+func TestMarshalPDUToStructMAC(t *testing.T) {
+	mac := []byte{0x00, 0x11, 0x22, 0xaa, 0xbb, 0xcc}
+	pdus := []snmp.SnmpPDU{
+		{Name: ".1.3.6.1.2.1.2.2.1.6.2", Type: snmp.OctetString, Value: mac},
+		{Name: ".1.3.6.1.2.1.17.1.1.0", Type: snmp.OctetString, Value: mac},
+		{Name: ".1.3.6.1.4.1.9999.1.0", Type: snmp.OctetString, Value: []byte("0011.22aa.bbcc")},
+	}
+	var info Test11
+	err := MarshalPDUsToStructE(pdus, &info)
+	if !errors.Is(err, ErrConversion) {
+		t.Errorf("MarshalPDUsToStructE() err = %v, want %v", err, ErrConversion)
+	}
+	if info.IfPhysAddress["2"].String() != "00:11:22:aa:bb:cc" {
+		t.Errorf("IfPhysAddress = %v", info.IfPhysAddress)
+	}
+	if info.BaseAddress != [6]byte{0x00, 0x11, 0x22, 0xaa, 0xbb, 0xcc} {
+		t.Errorf("BaseAddress = %v", info.BaseAddress)
+	}
+	info.TextMac = net.HardwareAddr(mac)
+	want := []snmp.SnmpPDU{
+		{Name: ".1.3.6.1.2.1.17.1.1.0", Type: snmp.OctetString, Value: mac},
+		{Name: ".1.3.6.1.4.1.9999.1.0", Type: snmp.OctetString, Value: mac},
+	}
+	if got := MarshalStructToPDUs(&info); !reflect.DeepEqual(got, want) {
+		t.Errorf("MarshalStructToPDUs() = %v, want %v", got, want)
+	}
+}
@@ -0,0 +1,62 @@
+package gosnmpHelper
+
+import (
+	"fmt"
+	"github.com/gosnmp/gosnmp"
+	"reflect"
+	"time"
+)
+
+var (
+	typeDuration = reflect.TypeOf(time.Duration(0))
+	typeTime     = reflect.TypeOf(time.Time{})
+)
+
+func getDurationValue(pdu gosnmp.SnmpPDU) (reflect.Value, error) {
+	switch pdu.Value.(type) {
+	case nil:
+		return reflect.ValueOf(time.Duration(0)), fmt.Errorf("%w: %w", ErrConversion, ErrNilValue)
+	case uint8, uint16, uint32, uint64, uint, int8, int16, int32, int64, int:
+		return reflect.ValueOf(GetAsDuration(pdu)), nil
+	}
+	return reflect.ValueOf(time.Duration(0)), fmt.Errorf("%w: %w", ErrConversion, ErrNotNumeric)
+}
+
+func getTimeValue(pdu gosnmp.SnmpPDU) (reflect.Value, error) {
+	t, err := parseDateAndTime(pdu.Value)
+	if err != nil {
+		return reflect.ValueOf(t), fmt.Errorf("%w: %w", ErrConversion, err)
+	}
+	return reflect.ValueOf(t), nil
+}
+
+// timePDUValue converts a time.Duration member into TimeTicks, or another numeric ASN.1 type, in hundredths
+// of a second.  A time.Time member is converted to an 11 byte DateAndTime OctetString.  If asnType is 0, the
+// types used are TimeTicks and OctetString respectively.
+func timePDUValue(asnType gosnmp.Asn1BER, v reflect.Value) (gosnmp.Asn1BER, interface{}, bool) {
+	switch t := v.Interface().(type) {
+	case time.Duration:
+		ticks := int64(t / (10 * time.Millisecond))
+		if asnType == 0 {
+			asnType = gosnmp.TimeTicks
+		}
+		val, ok := getPDUValue(asnType, reflect.ValueOf(ticks))
+		return asnType, val, ok && asnType != gosnmp.OctetString
+	case time.Time:
+		if asnType != 0 && asnType != gosnmp.OctetString {
+			return asnType, nil, false
+		}
+		_, offset := t.Zone()
+		dir := byte('+')
+		if offset < 0 {
+			dir = '-'
+			offset = -offset
+		}
+		return gosnmp.OctetString, []byte{
+			byte(t.Year() >> 8), byte(t.Year()), byte(t.Month()), byte(t.Day()),
+			byte(t.Hour()), byte(t.Minute()), byte(t.Second()), byte(t.Nanosecond() / 100000000),
+			dir, byte(offset / 3600), byte(offset % 3600 / 60),
+		}, !t.IsZero()
+	}
+	return asnType, nil, false
+}
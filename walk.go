@@ -0,0 +1,153 @@
+package gosnmpHelper
+
+import (
+	"context"
+	"errors"
+	"fmt"
+	"github.com/gosnmp/gosnmp"
+	"reflect"
+	"regexp"
+	"sort"
+	"strings"
+)
+
+/*
+WalkInto walks the subtrees needed to fill the members of dest with oidx and oidtable tags and marshals every
+returned PDU into dest, which must be a pointer to a struct.  For example:
+
+	type SysIntfs struct {
+		IfDesc       map[string]string `oidx:"\\.1\\.3\\.6\\.1\\.2\\.1\\.2\\.2\\.1\\.2\\.(\\d+)"`
+		IfOperStatus map[string]int    `oidx:"\\.1\\.3\\.6\\.1\\.2\\.1\\.2\\.2\\.1\\.8\\.(\\d+)"`
+	}
+	var intfs SysIntfs
+	err := WalkInto(ctx, client, &intfs)
+
+Here .1.3.6.1.2.1.2.2.1.2 and .1.3.6.1.2.1.2.2.1.8 are walked.  See Codec.WalkRoots() for how the subtrees
+are chosen.  SNMPv1 clients use Walk, others use BulkWalk.  The client must already be connected.  The
+context is used as the client's Context for the duration of the call, so the client must not be used by
+other goroutines at the same time.
+
+Members with plain oid tags are not fetched; see Fetch() for those.
+
+PDUs which cannot be marshaled do not stop the walk.  Their errors, along with any failed walks and members
+for which no subtree could be derived, are joined into the returned error.
+*/
+func WalkInto(ctx context.Context, client *gosnmp.GoSNMP, dest interface{}) error {
+	destT := reflect.TypeOf(dest)
+	if destT == nil || destT.Kind() != reflect.Ptr || destT.Elem().Kind() != reflect.Struct || reflect.ValueOf(dest).IsNil() {
+		return ErrNotPointer
+	}
+	c, err := NewCodec(destT)
+	if err != nil {
+		return err
+	}
+	var errs []error
+	roots, err := c.WalkRoots()
+	if err != nil {
+		errs = append(errs, err)
+	}
+	if ctx != nil {
+		saved := client.Context
+		client.Context = ctx
+		defer func() { client.Context = saved }()
+	}
+	walk := client.BulkWalk
+	if client.Version == gosnmp.Version1 {
+		walk = client.Walk
+	}
+	rows := rowIndexes{}
+	for _, root := range roots {
+		err = walk(root, func(pdu gosnmp.SnmpPDU) error {
+			if _, err := c.marshalRows(pdu, dest, rows); err != nil {
+				errs = append(errs, err)
+			}
+			return nil
+		})
+		if err != nil {
+			errs = append(errs, fmt.Errorf("walk %s: %w", root, err))
+			if ctx != nil && ctx.Err() != nil {
+				break
+			}
+		}
+	}
+	return errors.Join(errs...)
+}
+
+/*
+WalkRoots returns the subtrees which need to be walked to fill the members with oidx and oidtable tags,
+including those of nested structs.  For each member the subtree is:
+
+  - the OID in a walk tag, if present.  For example `walk:".1.3.6.1.2.1.17.7.1.2.2.1"`
+  - the table entry OID of an oidtable tag
+  - the longest run of whole sub-identifiers at the start of an oidx pattern which are plain literals,
+    so `\.1\.3\.6\.1\.2\.1\.2\.2\.1\.2\.(\d+)` gives .1.3.6.1.2.1.2.2.1.2
+
+Subtrees within another subtree in the list are dropped so nothing is walked twice, and the result is in
+SNMP order.  An error wrapping ErrNoWalkRoot is returned for members where no subtree could be found, such
+as an oidx pattern starting with a wildcard; add a walk tag to those.
+*/
+func (c *Codec) WalkRoots() ([]string, error) {
+	var (
+		roots []OID
+		errs  []error
+	)
+	c.collectRoots(&roots, &errs, "")
+	sort.Slice(roots, func(i, j int) bool { return roots[i].Compare(roots[j]) < 0 })
+	result := make([]string, 0, len(roots))
+	var last OID
+	for _, root := range roots {
+		if last != nil && root.HasPrefix(last) {
+			continue
+		}
+		result = append(result, root.String())
+		last = root
+	}
+	return result, errors.Join(errs...)
+}
+
+// collectRoots adds the walk roots of the Codec members to roots
+func (c *Codec) collectRoots(roots *[]OID, errs *[]error, path string) {
+	for i := range c.fields {
+		f := &c.fields[i]
+		root := f.walk
+		switch f.kind {
+		case fieldOidx:
+			if len(root) == 0 {
+				root = literalRoot(f.rx.String())
+			}
+		case fieldTable:
+			if len(root) == 0 {
+				root = f.table.entry
+			}
+		case fieldStruct, fieldPtr:
+			f.nested.collectRoots(roots, errs, joinPath(path, f.name))
+			continue
+		default:
+			continue
+		}
+		oid, err := ParseOID(root)
+		if err != nil {
+			*errs = append(*errs, fmt.Errorf("%s: %w", joinPath(path, f.name), ErrNoWalkRoot))
+			continue
+		}
+		*roots = append(*roots, oid)
+	}
+}
+
+// literalRoot returns the whole sub-identifiers at the start of an oidx pattern which are literal text
+func literalRoot(pattern string) string {
+	rx, err := regexp.Compile(strings.TrimPrefix(pattern, "^"))
+	if err != nil {
+		return ""
+	}
+	prefix, complete := rx.LiteralPrefix()
+	if !complete {
+		// The last sub-identifier may continue past the literal text, so it can't be used
+		if i := strings.LastIndex(prefix, "."); i >= 0 {
+			prefix = prefix[:i]
+		} else {
+			prefix = ""
+		}
+	}
+	return prefix
+}
@@ -0,0 +1,141 @@
+package gosnmpHelper
+
+import (
+	"context"
+	"errors"
+	snmp "github.com/gosnmp/gosnmp"
+	"github.com/jjcinaz/gosnmpHelper/snmptest"
+	"reflect"
+	"testing"
+)
+
+type walkMerged struct {
+	IfTable map[string]IfRow `oidtable:".1.3.6.1.2.1.2.2.1"`
+	// Within the table above
+	IfDesc map[string]string `oidx:"^\\.1\\.3\\.6\\.1\\.2\\.1\\.2\\.2\\.1\\.2\\.(\\d+)$"`
+	// Pattern ends part way through a sub-identifier, so .1.3.6.1.2.1.31.1.1.1
+	IfName map[string]string `oidx:"\\.1\\.3\\.6\\.1\\.2\\.1\\.31\\.1\\.1\\.1\\.1(\\d*)\\.(\\d+)"`
+	// Walk tag overrides the pattern
+	FdbPort map[string]int `oidx:"\\.1\\.3\\.6\\.1\\.2\\.1\\.(?:17\\.4\\.3|17\\.7\\.1\\.2\\.2)\\.1\\.2\\.(.+)" walk:"1.3.6.1.2.1.17"`
+	// Plain oid tags are not walked
+	SysDesc string `oid:".1.3.6.1.2.1.1.1.0"`
+}
+
+type walkUnknown struct {
+	Any    map[string]int `oidx:".*\\.(\\d+)"`
+	Nested struct {
+		Status map[string]int `oidx:"\\.1\\.3\\.6\\.1\\.2\\.1\\.2\\.2\\.1\\.8\\.(\\d+)"`
+	}
+}
+
+func TestWalkRoots(t *testing.T) {
+	tests := []struct {
+		name    string
+		t       reflect.Type
+		want    []string
+		wantErr bool
+	}{
+		{
+			name: "Nested pointer",
+			t:    reflect.TypeOf(SysInfo2{}),
+			want: []string{".1.3.6.1.2.1.2.2.1.2", ".1.3.6.1.2.1.2.2.1.8"},
+		},
+		{
+			name: "Tables",
+			t:    reflect.TypeOf(Test6{}),
+			want: []string{".1.3.6.1.2.1.2.2.1", ".1.3.6.1.2.1.4.22.1"},
+		},
+		{
+			name: "Compound indexes",
+			t:    reflect.TypeOf(Test8{}),
+			want: []string{".1.3.6.1.2.1.4.22.1.2", ".1.3.6.1.2.1.4.22.1.4",
+				".1.3.6.1.2.1.17.7.1.2.2.1.2", ".1.3.6.1.2.1.17.7.1.2.2.1.3"},
+		},
+		{
+			name: "Merged",
+			t:    reflect.TypeOf(walkMerged{}),
+			want: []string{".1.3.6.1.2.1.2.2.1", ".1.3.6.1.2.1.17", ".1.3.6.1.2.1.31.1.1.1"},
+		},
+		{
+			name:    "No root",
+			t:       reflect.TypeOf(walkUnknown{}),
+			want:    []string{".1.3.6.1.2.1.2.2.1.8"},
+			wantErr: true,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			c, err := NewCodec(tt.t)
+			if err != nil {
+				t.Fatal(err)
+			}
+			got, err := c.WalkRoots()
+			if (err != nil) != tt.wantErr || (err != nil && !errors.Is(err, ErrNoWalkRoot)) {
+				t.Fatalf("WalkRoots() err = %v, wantErr %v", err, tt.wantErr)
+			}
+			if !reflect.DeepEqual(got, tt.want) {
+				t.Errorf("WalkRoots() = %v, want %v", got, tt.want)
+			}
+		})
+	}
+}
+
+func TestWalkIntoNotPointer(t *testing.T) {
+	var info SysInfo1
+	if err := WalkInto(nil, nil, info); !errors.Is(err, ErrNotPointer) {
+		t.Errorf("WalkInto() err = %v, want ErrNotPointer", err)
+	}
+}
+
+type walkIfRow struct {
+	Index    string `oidcol:"index"`
+	Type     int    `oidcol:"3"`
+	InOctets uint32 `oidcol:"10"`
+}
+
+type walkInfo struct {
+	SysDesc string `oid:".1.3.6.1.2.1.1.1.0"`
+	// The first three members are all within the table walk
+	Intfs   SysIntfs
+	IfTable map[string]walkIfRow `oidtable:".1.3.6.1.2.1.2.2.1"`
+	Speed   map[string]uint32    `oidx:"\\.1\\.3\\.6\\.1\\.2\\.1\\.2\\.2\\.1\\.5\\.(\\d+)"`
+	Addrs   []string             `oid:".1.3.6.1.2.1.4.20.1.1"`
+	IPs     map[string]string    `oidx:"\\.1\\.3\\.6\\.1\\.2\\.1\\.4\\.20\\.1\\.1\\.([\\d.]+)"`
+}
+
+func TestWalkInto(t *testing.T) {
+	agent, err := snmptest.NewAgentFromWalkFile("testdata/router.walk")
+	if err != nil {
+		t.Fatal(err)
+	}
+	defer agent.Close()
+	client := agent.Client()
+
+	for _, version := range []snmp.SnmpVersion{snmp.Version2c, snmp.Version1} {
+		t.Run(version.String(), func(t *testing.T) {
+			client.Version = version
+			var info walkInfo
+			if err := WalkInto(context.Background(), client, &info); err != nil {
+				t.Fatalf("WalkInto() err = %v", err)
+			}
+			if len(info.SysDesc) > 0 {
+				t.Errorf("WalkInto() filled oid member SysDesc")
+			}
+			if want := map[string]string{"1": "lo", "2": "eth0", "6": "eth1"}; !reflect.DeepEqual(info.Intfs.IfDesc, want) {
+				t.Errorf("IfDesc = %v, want %v", info.Intfs.IfDesc, want)
+			}
+			if want := map[string]int{"1": 1, "2": 1, "6": 2}; !reflect.DeepEqual(info.Intfs.IfOperStatus, want) {
+				t.Errorf("IfOperStatus = %v, want %v", info.Intfs.IfOperStatus, want)
+			}
+			if want := (walkIfRow{Index: "2", Type: 6, InOctets: 4000000000}); info.IfTable["2"] != want || len(info.IfTable) != 3 {
+				t.Errorf("IfTable = %v", info.IfTable)
+			}
+			if info.Speed["2"] != 1000000000 || len(info.Speed) != 3 {
+				t.Errorf("Speed = %v", info.Speed)
+			}
+			if want := map[string]string{"10.0.0.1": "10.0.0.1", "127.0.0.1": "127.0.0.1"}; !reflect.DeepEqual(info.IPs, want) {
+				t.Errorf("IPs = %v, want %v", info.IPs, want)
+			}
+		})
+	}
+}